@@ -0,0 +1,5 @@
+data "fusion_array_performance" "array" {
+  region            = "us-east"
+  availability_zone = "east-dc-1"
+  name              = "flasharray1"
+}
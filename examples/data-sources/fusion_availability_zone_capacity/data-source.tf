@@ -0,0 +1,4 @@
+data "fusion_availability_zone_capacity" "availability_zone" {
+  region = "us-east"
+  name   = "east-dc-1"
+}
@@ -0,0 +1,8 @@
+data "fusion_capacity_report" "mongodb" {
+  tenant       = "database-team"
+  tenant_space = "mongodb"
+}
+
+output "mongodb_data_reduction" {
+  value = data.fusion_capacity_report.mongodb.provisioned_size / max(data.fusion_capacity_report.mongodb.unique_space, 1)
+}
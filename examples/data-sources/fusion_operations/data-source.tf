@@ -0,0 +1,12 @@
+# The latest changes to a volume, whoever made them
+data "fusion_operations" "vol1_changes" {
+  resource_kind = "Volume"
+  resource_id   = fusion_volume.vol1.id
+  created_after = "2023-01-31T08:00:00Z"
+  sort          = "created_at-"
+  limit         = 20
+}
+
+output "vol1_changes" {
+  value = [for op in data.fusion_operations.vol1_changes.items : "${op.request_type} ${op.status} ${op.update_fields}"]
+}
@@ -0,0 +1,5 @@
+data "fusion_placement_group_performance" "placement_group" {
+  tenant       = "database-team"
+  tenant_space = "mongodb"
+  name         = "db-shard-1"
+}
@@ -0,0 +1,5 @@
+data "fusion_placement_group_search" "on_array" {
+  region            = "us-east"
+  availability_zone = "east-dc-1"
+  array             = "flasharray1"
+}
@@ -0,0 +1,13 @@
+data "fusion_placement_group_sessions" "db_shard_1" {
+  tenant          = "database-team"
+  tenant_space    = "mongodb"
+  placement_group = "db-shard-1"
+}
+
+# Fails the plan if a host has no session to the placement group
+check "hosts_connected" {
+  assert {
+    condition     = contains(data.fusion_placement_group_sessions.db_shard_1.initiator_iqns, fusion_host_access_policy.db_host.iqn)
+    error_message = "The database host has no iSCSI session to db-shard-1."
+  }
+}
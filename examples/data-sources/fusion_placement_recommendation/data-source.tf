@@ -0,0 +1,22 @@
+data "fusion_placement_recommendation" "new_pg" {
+  tenant           = "database-team"
+  tenant_space     = "mongodb"
+  placement_engine = "pure1meta"
+  target_arrays    = ["array-1", "array-2"]
+
+  simulated_placement {
+    region            = "us-east"
+    availability_zone = "east-dc-1"
+    storage_service   = "db-high-performance"
+  }
+}
+
+resource "fusion_placement_group" "pg" {
+  name              = "pg1"
+  tenant            = "database-team"
+  tenant_space      = "mongodb"
+  region            = "us-east"
+  availability_zone = "east-dc-1"
+  storage_service   = "db-high-performance"
+  array             = data.fusion_placement_recommendation.new_pg.included_arrays[0].name
+}
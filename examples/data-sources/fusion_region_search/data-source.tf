@@ -0,0 +1,3 @@
+data "fusion_region_search" "us" {
+  display_name = "US East"
+}
@@ -0,0 +1,4 @@
+data "fusion_snapshot_search" "destroyed" {
+  destroyed = true
+  sort      = "time_remaining"
+}
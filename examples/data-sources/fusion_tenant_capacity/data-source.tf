@@ -0,0 +1,3 @@
+data "fusion_tenant_capacity" "tenant" {
+  name = "database-team"
+}
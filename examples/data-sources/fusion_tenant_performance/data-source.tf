@@ -0,0 +1,3 @@
+data "fusion_tenant_performance" "tenant" {
+  name = "database-team"
+}
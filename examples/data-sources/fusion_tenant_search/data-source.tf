@@ -0,0 +1,3 @@
+data "fusion_tenant_search" "by_id" {
+  tenant_id = "6b5c1d3e-0c2b-4f3f-9d8e-2a7b1c9e4f10"
+}
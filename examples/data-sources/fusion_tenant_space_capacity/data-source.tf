@@ -0,0 +1,4 @@
+data "fusion_tenant_space_capacity" "tenant_space" {
+  tenant = "database-team"
+  name   = "mongodb"
+}
@@ -0,0 +1,4 @@
+data "fusion_tenant_space_performance" "tenant_space" {
+  tenant = "database-team"
+  name   = "mongodb"
+}
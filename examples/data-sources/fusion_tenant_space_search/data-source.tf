@@ -0,0 +1,3 @@
+data "fusion_tenant_space_search" "mongodb" {
+  name = "mongodb"
+}
@@ -0,0 +1,5 @@
+data "fusion_version" "current" {}
+
+output "fusion_api_version" {
+  value = data.fusion_version.current.version
+}
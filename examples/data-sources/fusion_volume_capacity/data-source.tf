@@ -0,0 +1,5 @@
+data "fusion_volume_capacity" "volume" {
+  tenant       = "database-team"
+  tenant_space = "mongodb"
+  name         = "vol1"
+}
@@ -0,0 +1,5 @@
+data "fusion_volume_performance" "volume" {
+  tenant       = "database-team"
+  tenant_space = "mongodb"
+  name         = "vol1"
+}
@@ -0,0 +1,8 @@
+# Finds the Volume behind a device a host sees, e.g. /dev/disk/by-id/wwn-0x624a93708f3a1f5b4f9a4d1b00011c1f
+data "fusion_volume_search" "host_device" {
+  wwn = "wwn-0x624a93708f3a1f5b4f9a4d1b00011c1f"
+}
+
+output "host_device_volume" {
+  value = data.fusion_volume_search.host_device.items[0]
+}
@@ -10,3 +10,18 @@ resource "fusion_placement_group" "db_shard_1" {
   // Be careful! This will remove all snapshots in this placement group on deletion
   destroy_snapshots_on_delete = true
 }
+
+resource "fusion_placement_group" "db_shard_2" {
+  name              = "db-shard-2"
+  tenant            = "database-team"
+  tenant_space      = "mongodb"
+  availability_zone = "east-dc-1"
+  region            = "us-east"
+  storage_service   = "storage-service-generic"
+
+  // Let the placement engine choose the array when creating the placement group
+  array_selection {
+    placement_engine = "pure1meta"
+    exclude_arrays   = ["array-under-maintenance"]
+  }
+}
@@ -0,0 +1 @@
+terraform import fusion_snapshot.before_upgrade "/tenants/database-team/tenant-spaces/mongodb/snapshots/before-upgrade"
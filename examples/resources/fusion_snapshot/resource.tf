@@ -0,0 +1,17 @@
+resource "fusion_snapshot" "before_upgrade" {
+  name         = "before-upgrade"
+  display_name = "Before the upgrade"
+  tenant       = "database-team"
+  tenant_space = "mongodb"
+  volumes      = [fusion_volume.vol1.name, fusion_volume.vol2.name]
+
+  // Be careful using the below property, as this will make your snapshot un-recoverable
+  eradicate_on_delete = true
+}
+
+resource "fusion_snapshot" "db_shard_1" {
+  name            = "db-shard-1-nightly"
+  tenant          = "database-team"
+  tenant_space    = "mongodb"
+  placement_group = fusion_placement_group.db_shard_1.name
+}
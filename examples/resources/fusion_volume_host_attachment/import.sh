@@ -0,0 +1 @@
+terraform import fusion_volume_host_attachment.app_server "/tenants/database-team/tenant-spaces/mongodb/volumes/shared/host-access-policies/app-server-1"
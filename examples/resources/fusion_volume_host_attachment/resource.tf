@@ -0,0 +1,20 @@
+resource "fusion_volume" "shared" {
+  name            = "shared"
+  tenant          = "database-team"
+  tenant_space    = "mongodb"
+  storage_class   = "db-high-performance"
+  placement_group = "db-shard-1"
+  size            = "1T"
+
+  # The hosts are attached by fusion_volume_host_attachment, possibly in other configurations
+  lifecycle {
+    ignore_changes = [host_access_policies]
+  }
+}
+
+resource "fusion_volume_host_attachment" "app_server" {
+  tenant             = fusion_volume.shared.tenant
+  tenant_space       = fusion_volume.shared.tenant_space
+  volume             = fusion_volume.shared.name
+  host_access_policy = "app-server-1"
+}
@@ -54,18 +54,30 @@ func StringToPrivateKey(privateKeyString, privateKeyPassword string) (*rsa.Priva
 // This returns an access token that is good for one hour, in any exceptional cases it returns an empty string
 // privateKeyPassword is not a mandatory, it can be empty if private key doesn't encrypted
 func GetPure1SelfSignedAccessTokenGoodForOneHour(ctx context.Context, issuerId, privateKeyString, authNEndpoint, privateKeyPassword string) (string, error) {
-	privateKey, err := StringToPrivateKey(privateKeyString, privateKeyPassword)
+	token, err := GetPure1SelfSignedToken(ctx, issuerId, privateKeyString, authNEndpoint, privateKeyPassword)
 	if err != nil {
 		return "", err
 	}
+	return token.AccessToken, nil
+}
 
+// Same as GetPure1SelfSignedAccessTokenGoodForOneHour, but returns the whole token including its expiration time.
+// If the endpoint does not say when the token expires, the expiration of the identity token is used.
+func GetPure1SelfSignedToken(ctx context.Context, issuerId, privateKeyString, authNEndpoint, privateKeyPassword string) (*oauth2.Token, error) {
+	privateKey, err := StringToPrivateKey(privateKeyString, privateKeyPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(3600 * time.Second)
 	signedIdentityToken, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.StandardClaims{
 		Issuer:    issuerId,
-		IssuedAt:  time.Now().Unix(),
-		ExpiresAt: time.Now().Add(3600 * time.Second).Unix(),
+		IssuedAt:  issuedAt.Unix(),
+		ExpiresAt: expiresAt.Unix(),
 	}).SignedString(privateKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign identity token err:%w", err)
+		return nil, fmt.Errorf("failed to sign identity token err:%w", err)
 	}
 
 	config := oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: authNEndpoint}}
@@ -75,7 +87,10 @@ func GetPure1SelfSignedAccessTokenGoodForOneHour(ctx context.Context, issuerId,
 		oauth2.SetAuthURLParam("subject_token_type", "urn:ietf:params:oauth:token-type:jwt"),
 	)
 	if err != nil {
-		return "", fmt.Errorf("failed to exchange token endpoint:%s err:%w", authNEndpoint, err)
+		return nil, fmt.Errorf("failed to exchange token endpoint:%s err:%w", authNEndpoint, err)
+	}
+	if exchangedToken.Expiry.IsZero() {
+		exchangedToken.Expiry = expiresAt
 	}
-	return exchangedToken.AccessToken, nil
+	return exchangedToken, nil
 }
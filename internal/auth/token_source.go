@@ -0,0 +1,145 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// Pure1 access tokens are good for one hour. Renew them a bit earlier, so that a request
+// started just before the expiration does not get rejected on the way.
+const DefaultRefreshBeforeExpiry = 5 * time.Minute
+
+// TokenMinter obtains a brand new access token, e.g. by signing and exchanging a new identity token.
+type TokenMinter func(ctx context.Context) (*oauth2.Token, error)
+
+// RefreshingTokenSource caches the token returned by the minter and mints a new one
+// shortly before the cached one expires, or after it has been invalidated.
+type RefreshingTokenSource struct {
+	mint          TokenMinter
+	refreshBefore time.Duration
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func NewRefreshingTokenSource(mint TokenMinter, refreshBefore time.Duration) *RefreshingTokenSource {
+	return &RefreshingTokenSource{mint: mint, refreshBefore: refreshBefore}
+}
+
+// Token implements oauth2.TokenSource
+func (s *RefreshingTokenSource) Token() (*oauth2.Token, error) {
+	return s.TokenWithContext(context.Background())
+}
+
+// TokenWithContext returns the cached token, minting a new one (using ctx) if needed.
+func (s *RefreshingTokenSource) TokenWithContext(ctx context.Context) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && (s.token.Expiry.IsZero() || time.Now().Add(s.refreshBefore).Before(s.token.Expiry)) {
+		return s.token, nil
+	}
+
+	token, err := s.mint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+	return token, nil
+}
+
+// Invalidate drops the cached token if it is still the given one, so that the next call mints a new token.
+// Tokens which have been replaced in the meantime are ignored, so concurrent callers don't mint twice.
+func (s *RefreshingTokenSource) Invalidate(token *oauth2.Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = nil
+	}
+}
+
+// Transport is an http.RoundTripper which authorizes every request with a token from Source.
+// If Source is a *RefreshingTokenSource, requests rejected with 401 are retried once with a freshly minted token.
+type Transport struct {
+	Source oauth2.TokenSource
+	Base   http.RoundTripper
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	refreshing, canRefresh := t.Source.(*RefreshingTokenSource)
+
+	token, err := t.token(req.Context())
+	if err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+
+	resp, err := t.base().RoundTrip(authorizedRequest(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !canRefresh || !isReplayable(req) {
+		return resp, err
+	}
+
+	// The token got revoked or expired sooner than we expected, get a new one and try again
+	refreshing.Invalidate(token)
+	token, err = refreshing.TokenWithContext(req.Context())
+	if err != nil {
+		// Report the original 401 rather than the failure to mint a new token
+		return resp, nil
+	}
+
+	retryReq := authorizedRequest(req, token)
+	if req.Body != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retryReq.Body = body
+	}
+
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	return t.base().RoundTrip(retryReq)
+}
+
+func (t *Transport) token(ctx context.Context) (*oauth2.Token, error) {
+	if refreshing, ok := t.Source.(*RefreshingTokenSource); ok {
+		return refreshing.TokenWithContext(ctx)
+	}
+	return t.Source.Token()
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// RoundTrippers must not modify the request, so work on a copy with its own headers
+func authorizedRequest(req *http.Request, token *oauth2.Token) *http.Request {
+	clone := req.Clone(req.Context())
+	token.SetAuthHeader(clone)
+	return clone
+}
+
+func isReplayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/auth"
+)
+
+// Mints tokens "token-1", "token-2", ... which expire after the given duration
+func testCountingMinter(validFor time.Duration) (auth.TokenMinter, *int) {
+	minted := 0
+	return func(ctx context.Context) (*oauth2.Token, error) {
+		minted++
+		return &oauth2.Token{
+			AccessToken: fmt.Sprintf("token-%d", minted),
+			TokenType:   "Bearer",
+			Expiry:      time.Now().Add(validFor),
+		}, nil
+	}, &minted
+}
+
+func TestRefreshingTokenSource_reusesValidToken(t *testing.T) {
+	mint, minted := testCountingMinter(time.Hour)
+	source := auth.NewRefreshingTokenSource(mint, auth.DefaultRefreshBeforeExpiry)
+
+	for i := 0; i < 3; i++ {
+		token, err := source.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", token.AccessToken)
+	}
+	assert.Equal(t, 1, *minted)
+}
+
+func TestRefreshingTokenSource_refreshesBeforeExpiry(t *testing.T) {
+	mint, minted := testCountingMinter(time.Minute)
+	source := auth.NewRefreshingTokenSource(mint, auth.DefaultRefreshBeforeExpiry)
+
+	first, err := source.Token()
+	require.NoError(t, err)
+	second, err := source.Token()
+	require.NoError(t, err)
+
+	assert.Equal(t, "token-1", first.AccessToken)
+	assert.Equal(t, "token-2", second.AccessToken)
+	assert.Equal(t, 2, *minted)
+}
+
+func TestRefreshingTokenSource_invalidate(t *testing.T) {
+	mint, minted := testCountingMinter(time.Hour)
+	source := auth.NewRefreshingTokenSource(mint, auth.DefaultRefreshBeforeExpiry)
+
+	stale, err := source.Token()
+	require.NoError(t, err)
+
+	source.Invalidate(stale)
+	fresh, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", fresh.AccessToken)
+
+	// Invalidating a token which has already been replaced does not throw away the fresh one
+	source.Invalidate(stale)
+	current, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", current.AccessToken)
+	assert.Equal(t, 2, *minted)
+}
+
+func TestTransport_retriesUnauthorizedWithNewToken(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		seen = append(seen, r.Header.Get("Authorization")+" "+string(body))
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	mint, minted := testCountingMinter(time.Hour)
+	client := &http.Client{Transport: &auth.Transport{Source: auth.NewRefreshingTokenSource(mint, auth.DefaultRefreshBeforeExpiry)}}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"name":"vol1"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{`Bearer token-1 {"name":"vol1"}`, `Bearer token-2 {"name":"vol1"}`}, seen)
+	assert.Equal(t, 2, *minted)
+}
+
+func TestTransport_staticTokenIsNotRetried(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "Bearer static", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	source := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "static", TokenType: "Bearer"})
+	client := &http.Client{Transport: &auth.Transport{Source: source}}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fakefusion
+
+import (
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+)
+
+// Changes the resources once the operation succeeds, returning the resource it operated on
+type applyFunc func() (object, *hmrest.ModelError)
+
+type operation struct {
+	model hmrest.Operation
+	apply applyFunc
+	fault *Fault
+	polls int
+}
+
+// Fault makes the requests it matches fail, or the operations they start
+type Fault struct {
+	// Matches any method when empty
+	Method string
+	// Pattern of the paths under BasePath it matches, as in path.Match, e.g., /tenants/*/tenant-spaces/*/volumes/*.
+	// Matches any path when empty.
+	Path string
+	// The request fails with this HTTP code, e.g., 503. When 0, the request starts an operation that fails.
+	HttpCode int32
+	// Defaults to INTERNAL
+	PureCode string
+	// Defaults to a message naming the fault
+	Message string
+	// How many matching requests fail, 0 for all of them
+	Times int
+
+	hits int
+}
+
+func (f *Fault) pureCode() string {
+	if f.PureCode == "" {
+		return "INTERNAL"
+	}
+	return f.PureCode
+}
+
+func (f *Fault) message() string {
+	if f.Message == "" {
+		return "injected fault"
+	}
+	return f.Message
+}
+
+// Makes the matching requests or operations fail, see Fault
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// Returns the first fault matching the request. Faults without an HTTP code only match requests starting operations.
+func (s *Server) matchFault(method, requestPath string) *Fault {
+	for _, fault := range s.faults {
+		if fault.Times != 0 && fault.hits >= fault.Times {
+			continue
+		}
+		if fault.Method != "" && !strings.EqualFold(fault.Method, method) {
+			continue
+		}
+		if fault.HttpCode == 0 && method == http.MethodGet {
+			continue
+		}
+		if fault.Path != "" {
+			if matched, _ := path.Match(fault.Path, requestPath); !matched {
+				continue
+			}
+		}
+		fault.hits++
+		return fault
+	}
+	return nil
+}
+
+func (s *Server) startOperation(method, requestPath, requestId string, apply applyFunc, fault *Fault) *operation {
+	action := map[string]string{http.MethodPost: "Create", http.MethodPatch: "Update", http.MethodDelete: "Delete"}[method]
+	collection := requestPath
+	if method != http.MethodPost {
+		collection = parentPath(requestPath) + "/" + collectionOf(requestPath)
+	}
+
+	id := s.newId()
+	now := millis(time.Now())
+	op := &operation{
+		model: hmrest.Operation{
+			Id:                id,
+			SelfLink:          "/operations/" + id,
+			RequestType:       action + kinds[path.Base(collection)].name,
+			RequestId:         requestId,
+			RequestCollection: parentPath(collection) + "/",
+			CreatedAt:         now,
+		},
+		apply: apply,
+		fault: fault,
+	}
+	s.operations[id] = op
+	s.advance(op)
+	return op
+}
+
+// Moves the operation to its next status, applying it when it ends
+func (s *Server) advance(op *operation) {
+	if op.model.Status == "Succeeded" || op.model.Status == "Failed" {
+		return
+	}
+
+	statuses := s.OperationStatuses
+	if len(statuses) == 0 {
+		statuses = []string{"Succeeded"}
+	}
+	if op.polls < len(statuses)-1 {
+		op.model.Status = statuses[op.polls]
+		op.model.RetryIn = s.RetryIn
+		if op.model.Status == "Running" && op.model.StartedAt == 0 {
+			op.model.StartedAt = millis(time.Now())
+		}
+		op.polls++
+		return
+	}
+
+	now := millis(time.Now())
+	if op.model.StartedAt == 0 {
+		op.model.StartedAt = now
+	}
+	op.model.EndedAt = now
+	op.model.RetryIn = 0
+
+	if op.fault != nil {
+		op.fail(&hmrest.ModelError{Message: op.fault.message(), PureCode: op.fault.pureCode(), HttpCode: http.StatusInternalServerError})
+		return
+	}
+	result, modelErr := op.apply()
+	if modelErr != nil {
+		op.fail(modelErr)
+		return
+	}
+	op.model.Status = "Succeeded"
+	op.model.Result = &hmrest.OperationResult{Resource: &hmrest.ResourceReference{
+		Id:       result["id"].(string),
+		Name:     result["name"].(string),
+		Kind:     result["kind"].(string),
+		SelfLink: result["self_link"].(string),
+	}}
+}
+
+func (op *operation) fail(modelErr *hmrest.ModelError) {
+	op.model.Status = "Failed"
+	op.model.Error_ = modelErr
+}
+
+// Serves /operations/<id>, advancing the operation on each poll, and the list of all operations
+func (s *Server) serveOperations(w http.ResponseWriter, r *http.Request, segments []string) {
+	if r.Method != http.MethodGet || len(segments) > 2 {
+		writeError(w, http.StatusNotImplemented, "NOT_IMPLEMENTED", "%s %s is not implemented by the fake", r.Method, r.URL.Path)
+		return
+	}
+
+	if len(segments) == 1 {
+		items := []hmrest.Operation{}
+		for _, op := range s.operations {
+			items = append(items, op.model)
+		}
+		writeJSON(w, http.StatusOK, hmrest.OperationList{Count: int32(len(items)), Items: items})
+		return
+	}
+
+	op, ok := s.operations[segments[1]]
+	if !ok {
+		writeNotFound(w, "operation "+segments[1])
+		return
+	}
+	s.advance(op)
+	writeJSON(w, http.StatusOK, op.model)
+}
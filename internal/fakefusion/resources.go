@@ -0,0 +1,500 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fakefusion
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+)
+
+// How long a destroyed resource is kept before it is eradicated, in milliseconds
+const destroyedTimeRemaining = 24 * 60 * 60 * 1000
+
+type kind struct {
+	// Reported in references, e.g., Volume
+	name string
+	// Field referring to it from the resources nested in it, e.g., tenant_space
+	field string
+	// Collection it is nested in, empty at the top level
+	parent string
+	// Must be destroyed before it can be deleted
+	destroyFirst bool
+	// Deleting it deletes the resources nested in it, instead of failing
+	cascade bool
+}
+
+// The collections the fake serves, by the path segment naming them
+var kinds = map[string]kind{
+	"tenants":              {name: "Tenant", field: "tenant"},
+	"tenant-spaces":        {name: "TenantSpace", field: "tenant_space", parent: "tenants"},
+	"placement-groups":     {name: "PlacementGroup", field: "placement_group", parent: "tenant-spaces"},
+	"volumes":              {name: "Volume", field: "volume", parent: "tenant-spaces", destroyFirst: true},
+	"snapshots":            {name: "Snapshot", field: "snapshot", parent: "tenant-spaces", destroyFirst: true, cascade: true},
+	"volume-snapshots":     {name: "VolumeSnapshot", field: "volume_snapshot", parent: "snapshots"},
+	"host-access-policies": {name: "HostAccessPolicy", field: "host_access_policy"},
+	"protection-policies":  {name: "ProtectionPolicy", field: "protection_policy"},
+	"storage-services":     {name: "StorageService", field: "storage_service"},
+	"storage-classes":      {name: "StorageClass", field: "storage_class", parent: "storage-services"},
+	"hardware-types":       {name: "HardwareType", field: "hardware_type"},
+	"regions":              {name: "Region", field: "region"},
+	"availability-zones":   {name: "AvailabilityZone", field: "availability_zone", parent: "regions"},
+	"arrays":               {name: "Array", field: "array", parent: "availability-zones"},
+}
+
+// Fields of the requests naming other resources, by the collection the names are looked up in
+var refFields = map[string]string{
+	"storage_service":      "storage-services",
+	"storage_class":        "storage-classes",
+	"placement_group":      "placement-groups",
+	"protection_policy":    "protection-policies",
+	"host_access_policies": "host-access-policies",
+	"hardware_types":       "hardware-types",
+	"hardware_type":        "hardware-types",
+	"region":               "regions",
+	"availability_zone":    "availability-zones",
+	"array":                "arrays",
+}
+
+// References that do not keep the resource they refer to from being deleted
+var weakRefs = map[string]bool{"volume": true, "source": true, "source_volume_snapshot": true}
+
+// Reports whether the path alternates between collections the fake serves and resource names,
+// each collection nested in its parent
+func knownPath(segments []string) bool {
+	parent := ""
+	for i := 0; i < len(segments); i += 2 {
+		k, ok := kinds[segments[i]]
+		if !ok || k.parent != parent {
+			return false
+		}
+		parent = segments[i]
+	}
+	return true
+}
+
+// Returns the self link of the resource a collection or a resource is nested in, empty at the top level
+func parentPath(p string) string {
+	segments := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	if len(segments)%2 == 0 {
+		segments = segments[:len(segments)-2]
+	} else {
+		segments = segments[:len(segments)-1]
+	}
+	if len(segments) == 0 {
+		return ""
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// Returns the collection of a self link, e.g., volumes for /tenants/<t>/tenant-spaces/<ts>/volumes/<v>
+func collectionOf(selfLink string) string {
+	segments := strings.Split(selfLink, "/")
+	if len(segments) < 2 {
+		return ""
+	}
+	return segments[len(segments)-2]
+}
+
+func reference(obj object) object {
+	return object{"id": obj["id"], "name": obj["name"], "kind": obj["kind"], "self_link": obj["self_link"]}
+}
+
+func modelError(status int, pureCode, format string, args ...interface{}) *hmrest.ModelError {
+	return &hmrest.ModelError{Message: fmt.Sprintf(format, args...), PureCode: pureCode, HttpCode: int32(status)}
+}
+
+func notFound(format string, args ...interface{}) *hmrest.ModelError {
+	return modelError(http.StatusNotFound, "NOT_FOUND", format, args...)
+}
+
+func failedPrecondition(format string, args ...interface{}) *hmrest.ModelError {
+	return modelError(http.StatusPreconditionFailed, "FAILED_PRECONDITION", format, args...)
+}
+
+// Finds a resource of the collection by name. When several have that name, e.g., Placement Groups
+// of different Tenant Spaces, the one nested closest to the given self link wins.
+func (s *Server) lookup(collection, name, near string) object {
+	var found object
+	bestPrefix := -1
+	for selfLink, obj := range s.objects {
+		if collectionOf(selfLink) != collection || obj["name"] != name {
+			continue
+		}
+		prefix := commonPrefixLength(parentPath(selfLink)+"/", near)
+		if prefix > bestPrefix || (prefix == bestPrefix && selfLink < found["self_link"].(string)) {
+			found, bestPrefix = obj, prefix
+		}
+	}
+	return found
+}
+
+func commonPrefixLength(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+func (s *Server) prepareCreate(collection string, body map[string]interface{}) (applyFunc, error) {
+	name, _ := body["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	k := kinds[collectionOf(collection+"/"+name)]
+
+	return func() (object, *hmrest.ModelError) {
+		selfLink := collection + "/" + name
+		if s.objects[selfLink] != nil {
+			return nil, modelError(http.StatusConflict, "ALREADY_EXISTS", "%s %s already exists", k.name, name)
+		}
+
+		obj := object{"id": s.newId(), "name": name, "display_name": name, "self_link": selfLink, "kind": k.name}
+		for ancestor := parentPath(selfLink); ancestor != ""; ancestor = parentPath(ancestor) {
+			parent := s.objects[ancestor]
+			if parent == nil {
+				return nil, notFound("%s not found", ancestor)
+			}
+			obj[kinds[collectionOf(ancestor)].field] = reference(parent)
+		}
+
+		fields := map[string]interface{}{}
+		for field, value := range body {
+			fields[field] = value
+		}
+		var volumes []object
+		if k.name == "Snapshot" {
+			var modelErr *hmrest.ModelError
+			if volumes, modelErr = s.snapshotVolumes(obj, fields); modelErr != nil {
+				return nil, modelErr
+			}
+		}
+		if modelErr := s.setFields(obj, fields); modelErr != nil {
+			return nil, modelErr
+		}
+
+		now := millis(time.Now())
+		switch k.name {
+		case "Volume":
+			obj["serial_number"] = s.newSerialNumber()
+			obj["created_at"] = now
+			obj["destroyed"] = false
+			s.placeVolume(obj)
+		case "PlacementGroup":
+			obj["protocols"] = object{"iscsi": object{"iqn": "iqn.2010-06.com.purestorage:flasharray." + strings.ToLower(s.newSerialNumber())}}
+			if _, ok := obj["array"]; !ok {
+				if array := s.firstArray(obj); array != nil {
+					obj["array"] = reference(array)
+				}
+			}
+		case "Snapshot":
+			obj["created_at"] = now
+			obj["destroyed"] = false
+			obj["volume_snapshots_link"] = selfLink + "/volume-snapshots"
+		}
+
+		s.objects[selfLink] = obj
+		for _, volume := range volumes {
+			s.createVolumeSnapshot(obj, volume, now)
+		}
+		return obj, nil
+	}, nil
+}
+
+func (s *Server) prepareUpdate(selfLink string, body map[string]interface{}) applyFunc {
+	return func() (object, *hmrest.ModelError) {
+		current := s.objects[selfLink]
+		if current == nil {
+			return nil, notFound("%s not found", selfLink)
+		}
+
+		obj := object{}
+		for field, value := range current {
+			obj[field] = value
+		}
+		fields := map[string]interface{}{}
+		for field, value := range body {
+			if field == "name" {
+				continue
+			}
+			// Patches wrap their values, e.g., {"size": {"value": 1048576}}
+			if wrapper, ok := value.(map[string]interface{}); ok && len(wrapper) == 1 {
+				if inner, ok := wrapper["value"]; ok {
+					value = inner
+				}
+			}
+			fields[field] = value
+		}
+		if modelErr := s.setFields(obj, fields); modelErr != nil {
+			return nil, modelErr
+		}
+
+		switch obj["kind"] {
+		case "Volume":
+			if obj["destroyed"] == true && len(refList(obj["host_access_policies"])) > 0 {
+				return nil, failedPrecondition("cannot destroy volume %s while it is connected to hosts", obj["name"])
+			}
+			s.placeVolume(obj)
+		case "PlacementGroup":
+			s.objects[selfLink] = obj
+			for _, volume := range s.objects {
+				if volume["kind"] == "Volume" && refSelfLink(volume["placement_group"]) == selfLink {
+					s.placeVolume(volume)
+				}
+			}
+		}
+
+		s.objects[selfLink] = obj
+		return obj, nil
+	}
+}
+
+func (s *Server) prepareDelete(selfLink string) applyFunc {
+	return func() (object, *hmrest.ModelError) {
+		obj := s.objects[selfLink]
+		if obj == nil {
+			return nil, notFound("%s not found", selfLink)
+		}
+		k := kinds[collectionOf(selfLink)]
+
+		if k.destroyFirst && obj["destroyed"] != true {
+			return nil, failedPrecondition("%s %s must be destroyed before it is eradicated", k.name, obj["name"])
+		}
+
+		nested := []string{}
+		for other := range s.objects {
+			if strings.HasPrefix(other, selfLink+"/") {
+				nested = append(nested, other)
+			}
+		}
+		sort.Strings(nested)
+		if len(nested) > 0 && !k.cascade {
+			return nil, failedPrecondition("%s %s is not empty, it contains %s", k.name, obj["name"], nested[0])
+		}
+
+		if referrer := s.referrer(selfLink); referrer != "" {
+			return nil, failedPrecondition("%s %s is in use by %s", k.name, obj["name"], referrer)
+		}
+
+		for _, other := range nested {
+			delete(s.objects, other)
+		}
+		delete(s.objects, selfLink)
+		return obj, nil
+	}
+}
+
+// Returns the self link of a resource keeping the given one from being deleted, empty if there is none.
+// The resources nested in it do not count.
+func (s *Server) referrer(selfLink string) string {
+	referrers := []string{}
+	for other, obj := range s.objects {
+		if other == selfLink || strings.HasPrefix(other, selfLink+"/") {
+			continue
+		}
+		for field, value := range obj {
+			if weakRefs[field] {
+				continue
+			}
+			if refSelfLink(value) == selfLink {
+				referrers = append(referrers, other)
+			}
+			for _, ref := range refList(value) {
+				if ref["self_link"] == selfLink {
+					referrers = append(referrers, other)
+				}
+			}
+		}
+	}
+	if len(referrers) == 0 {
+		return ""
+	}
+	sort.Strings(referrers)
+	return referrers[0]
+}
+
+// Sets the fields of a request on the resource, turning the names of other resources into references to them
+func (s *Server) setFields(obj object, fields map[string]interface{}) *hmrest.ModelError {
+	selfLink := obj["self_link"].(string)
+
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	for _, field := range names {
+		value := fields[field]
+		switch field {
+		case "host_access_policies", "hardware_types":
+			var refNames []string
+			switch value := value.(type) {
+			case string: // Volume patches list Host Access Policies separated by commas
+				refNames = strings.Split(value, ",")
+			case []interface{}:
+				for _, name := range value {
+					refNames = append(refNames, fmt.Sprint(name))
+				}
+			}
+			refs := []object{}
+			for _, name := range refNames {
+				if name == "" {
+					continue
+				}
+				ref := s.lookup(refFields[field], name, selfLink)
+				if ref == nil {
+					return notFound("%s %s not found", refFields[field], name)
+				}
+				refs = append(refs, reference(ref))
+			}
+			obj[field] = refs
+		case "source_link", "source_volume_snapshot_link":
+			source := s.objects[fmt.Sprint(value)]
+			if source == nil {
+				return notFound("%s not found", value)
+			}
+			obj[strings.TrimSuffix(field, "_link")] = reference(source)
+			obj["size"] = source["size"]
+		case "destroyed":
+			destroyed, _ := value.(bool)
+			obj[field] = destroyed
+			if destroyed {
+				obj["time_remaining"] = destroyedTimeRemaining
+			} else {
+				delete(obj, "time_remaining")
+			}
+		default:
+			collection, isRef := refFields[field]
+			if !isRef {
+				obj[field] = value
+				continue
+			}
+			name := fmt.Sprint(value)
+			if name == "" {
+				delete(obj, field)
+				continue
+			}
+			near := selfLink
+			switch field {
+			case "availability_zone":
+				near = fmt.Sprintf("/regions/%v/", fields["region"])
+			case "array":
+				near = refSelfLink(obj["availability_zone"]) + "/"
+			}
+			ref := s.lookup(collection, name, near)
+			if ref == nil {
+				return notFound("%s %s not found", collection, name)
+			}
+			obj[field] = reference(ref)
+		}
+	}
+	return nil
+}
+
+// Collects the Volumes a Snapshot request names, or those of the Placement Group it names
+func (s *Server) snapshotVolumes(snapshot object, fields map[string]interface{}) ([]object, *hmrest.ModelError) {
+	tenantSpace := parentPath(snapshot["self_link"].(string))
+	volumes := []object{}
+
+	if names, ok := fields["volumes"].([]interface{}); ok {
+		for _, name := range names {
+			volume := s.objects[fmt.Sprintf("%s/volumes/%v", tenantSpace, name)]
+			if volume == nil {
+				return nil, notFound("volume %v not found", name)
+			}
+			volumes = append(volumes, volume)
+		}
+	}
+	if placementGroup, ok := fields["placement_group"].(string); ok && placementGroup != "" {
+		placementGroupLink := fmt.Sprintf("%s/placement-groups/%s", tenantSpace, placementGroup)
+		if s.objects[placementGroupLink] == nil {
+			return nil, notFound("placement group %s not found", placementGroup)
+		}
+		for _, volume := range s.children(tenantSpace + "/volumes") {
+			if refSelfLink(volume["placement_group"]) == placementGroupLink && volume["destroyed"] != true {
+				volumes = append(volumes, volume)
+			}
+		}
+	}
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i]["name"].(string) < volumes[j]["name"].(string) })
+
+	delete(fields, "volumes")
+	delete(fields, "placement_group")
+	return volumes, nil
+}
+
+func (s *Server) createVolumeSnapshot(snapshot, volume object, now int64) {
+	selfLink := fmt.Sprintf("%s/volume-snapshots/%s", snapshot["self_link"], volume["name"])
+	volumeSnapshot := object{
+		"id":                   s.newId(),
+		"name":                 volume["name"],
+		"display_name":         volume["name"],
+		"self_link":            selfLink,
+		"kind":                 kinds["volume-snapshots"].name,
+		"serial_number":        s.newSerialNumber(),
+		"volume_serial_number": volume["serial_number"],
+		"consistency_id":       snapshot["id"],
+		"created_at":           now,
+		"destroyed":            false,
+		"size":                 volume["size"],
+		"tenant":               snapshot["tenant"],
+		"tenant_space":         snapshot["tenant_space"],
+		"snapshot":             reference(snapshot),
+		"volume":               reference(volume),
+		"placement_group":      volume["placement_group"],
+	}
+	if protectionPolicy, ok := volume["protection_policy"]; ok {
+		volumeSnapshot["protection_policy"] = protectionPolicy
+	}
+	s.objects[selfLink] = volumeSnapshot
+}
+
+// Puts the Volume on the Array of its Placement Group
+func (s *Server) placeVolume(volume object) {
+	placementGroup := s.objects[refSelfLink(volume["placement_group"])]
+	if placementGroup == nil {
+		return
+	}
+	if array, ok := placementGroup["array"]; ok {
+		volume["array"] = array
+	}
+	if protocols, ok := placementGroup["protocols"]; ok {
+		volume["target"] = protocols
+	}
+}
+
+// Returns the first Array of the Availability Zone of the Placement Group
+func (s *Server) firstArray(placementGroup object) object {
+	arrays := s.children(refSelfLink(placementGroup["availability_zone"]) + "/arrays")
+	if len(arrays) == 0 {
+		return nil
+	}
+	sort.Slice(arrays, func(i, j int) bool { return arrays[i]["name"].(string) < arrays[j]["name"].(string) })
+	return arrays[0]
+}
+
+func (s *Server) newSerialNumber() string {
+	s.lastId++
+	return fmt.Sprintf("8F3A1F5B4F9A4D1B%08X", s.lastId)
+}
+
+func refSelfLink(value interface{}) string {
+	if ref, ok := value.(object); ok {
+		link, _ := ref["self_link"].(string)
+		return link
+	}
+	return ""
+}
+
+func refList(value interface{}) []object {
+	refs, _ := value.([]object)
+	return refs
+}
@@ -0,0 +1,377 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package fakefusion is an in-memory fake of the Fusion REST API. It serves the resources the provider
+// manages over HTTP, so that the provider can be tested offline and without credentials.
+package fakefusion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+)
+
+// The fake serves the API under the same base path as Fusion, so its URL can be used as the provider host
+const BasePath = "/api/v1"
+
+// Seeded in every new Server, matching the defaults of the acceptance tests
+const (
+	DefaultRegion           = "pure-us-west"
+	DefaultAvailabilityZone = "az1"
+	DefaultArray            = "array1"
+)
+
+// A resource as returned by the API, keyed by its JSON field names
+type object map[string]interface{}
+
+type Server struct {
+	*httptest.Server
+
+	// The statuses an operation goes through, one per poll. The last one ends the operation,
+	// it is Succeeded unless a Fault makes the operation fail.
+	OperationStatuses []string
+	// Time the client is asked to wait before polling an unfinished operation again, in milliseconds
+	RetryIn int32
+	// Reported by /info/version
+	Version string
+
+	mu         sync.Mutex
+	objects    map[string]object // self link -> resource
+	operations map[string]*operation
+	faults     []*Fault
+	requests   []string
+	lastId     int
+}
+
+// Starts a fake Fusion with a region, an availability zone, an array and the hardware types already in place
+func NewServer() *Server {
+	s := &Server{
+		OperationStatuses: []string{"Pending", "Running", "Succeeded"},
+		RetryIn:           10,
+		Version:           "1.2",
+		objects:           map[string]object{},
+		operations:        map[string]*operation{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	for _, hardwareType := range []string{"flash-array-x", "flash-array-c", "flash-array-x-optane", "flash-array-xl"} {
+		s.mustSeed("/hardware-types", object{"name": hardwareType, "array_type": hardwareType, "media_type": hardwareType})
+	}
+	s.mustSeed("/regions", object{"name": DefaultRegion})
+	s.mustSeed("/regions/"+DefaultRegion+"/availability-zones", object{"name": DefaultAvailabilityZone})
+	s.mustSeed("/regions/"+DefaultRegion+"/availability-zones/"+DefaultAvailabilityZone+"/arrays", object{
+		"name": DefaultArray, "hardware_type": "flash-array-x", "apartment_id": "apartment1", "appliance_id": "appliance1", "host_name": DefaultArray,
+	})
+
+	return s
+}
+
+// Returns an API client talking to the fake without authentication
+func (s *Server) Client() *hmrest.APIClient {
+	return hmrest.NewAPIClient(&hmrest.Configuration{
+		BasePath:      s.URL + BasePath,
+		DefaultHeader: map[string]string{},
+		HTTPClient:    s.Server.Client(),
+	})
+}
+
+// Adds a resource to the collection right away, without going through an operation.
+// The body is the same as the one of a POST to the collection, e.g., {"name": "pg1", "storage_service": "ss1", ...}.
+func (s *Server) Seed(collection string, body map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	apply, err := s.prepareCreate(strings.TrimSuffix(collection, "/"), body)
+	if err != nil {
+		return err
+	}
+	if _, modelErr := apply(); modelErr != nil {
+		return fmt.Errorf("%s", modelErr.Message)
+	}
+	return nil
+}
+
+func (s *Server) mustSeed(collection string, body map[string]interface{}) {
+	if err := s.Seed(collection, body); err != nil {
+		panic(err)
+	}
+}
+
+// Returns the method and path of each request served so far, e.g., "POST /tenants"
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+// Returns the self links of the resources that currently exist, sorted
+func (s *Server) SelfLinks() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	selfLinks := make([]string, 0, len(s.objects))
+	for selfLink := range s.objects {
+		selfLinks = append(selfLinks, selfLink)
+	}
+	sort.Strings(selfLinks)
+	return selfLinks
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requestId := r.Header.Get("X-Request-ID")
+	if requestId == "" {
+		requestId = s.newId()
+	}
+	w.Header().Set("X-Request-ID", requestId)
+
+	if !strings.HasPrefix(r.URL.Path, BasePath+"/") {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "%s is not under %s", r.URL.Path, BasePath)
+		return
+	}
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, BasePath), "/")
+	s.requests = append(s.requests, r.Method+" "+path)
+
+	fault := s.matchFault(r.Method, path)
+	if fault != nil && fault.HttpCode != 0 {
+		writeError(w, int(fault.HttpCode), fault.pureCode(), "%s", fault.message())
+		return
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	switch {
+	case path == "/info/version" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, hmrest.Version{Version: s.Version})
+	case segments[0] == "operations":
+		s.serveOperations(w, r, segments)
+	case segments[0] == "resources":
+		s.serveResources(w, r, segments)
+	case segments[len(segments)-1] == "space" && r.Method == http.MethodGet:
+		s.serveSpace(w, strings.TrimSuffix(path, "/space"))
+	default:
+		s.serveTree(w, r, path, segments, requestId, fault)
+	}
+}
+
+// Serves the resources by their self links, e.g., /tenants/<t>/tenant-spaces/<ts>/volumes/<v>
+func (s *Server) serveTree(w http.ResponseWriter, r *http.Request, path string, segments []string, requestId string, fault *Fault) {
+	if !knownPath(segments) {
+		writeError(w, http.StatusNotImplemented, "NOT_IMPLEMENTED", "%s %s is not implemented by the fake", r.Method, path)
+		return
+	}
+	isCollection := len(segments)%2 == 1
+
+	var body map[string]interface{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPatch {
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		if err := decoder.Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "cannot decode the request body: %s", err)
+			return
+		}
+	}
+
+	var apply applyFunc
+	var err error
+	switch {
+	case isCollection && r.Method == http.MethodGet:
+		if parent := parentPath(path); parent != "" && s.objects[parent] == nil {
+			writeNotFound(w, parent)
+			return
+		}
+		s.writeList(w, r, s.children(path))
+		return
+	case !isCollection && r.Method == http.MethodGet:
+		if obj := s.objects[path]; obj != nil {
+			writeJSON(w, http.StatusOK, obj)
+		} else {
+			writeNotFound(w, path)
+		}
+		return
+	case isCollection && r.Method == http.MethodPost:
+		if parent := parentPath(path); parent != "" && s.objects[parent] == nil {
+			writeNotFound(w, parent)
+			return
+		}
+		apply, err = s.prepareCreate(path, body)
+	case !isCollection && r.Method == http.MethodPatch:
+		if s.objects[path] == nil {
+			writeNotFound(w, path)
+			return
+		}
+		apply = s.prepareUpdate(path, body)
+	case !isCollection && r.Method == http.MethodDelete:
+		if s.objects[path] == nil {
+			writeNotFound(w, path)
+			return
+		}
+		apply = s.prepareDelete(path)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "INVALID_ARGUMENT", "%s is not allowed on %s", r.Method, path)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "%s", err)
+		return
+	}
+
+	op := s.startOperation(r.Method, path, requestId, apply, fault)
+	writeJSON(w, http.StatusAccepted, op.model)
+}
+
+// Serves /resources/<collection>/<id> and the queries of /resources/<collection>
+func (s *Server) serveResources(w http.ResponseWriter, r *http.Request, segments []string) {
+	if r.Method != http.MethodGet || len(segments) < 2 || len(segments) > 3 {
+		writeError(w, http.StatusNotImplemented, "NOT_IMPLEMENTED", "%s %s is not implemented by the fake", r.Method, r.URL.Path)
+		return
+	}
+	collection := segments[1]
+	if collection == "operations" {
+		s.serveOperations(w, r, segments[1:])
+		return
+	}
+
+	matches := []object{}
+	for selfLink, obj := range s.objects {
+		if collectionOf(selfLink) == collection {
+			matches = append(matches, obj)
+		}
+	}
+
+	if len(segments) == 2 {
+		s.writeList(w, r, matches)
+		return
+	}
+	for _, obj := range matches {
+		if obj["id"] == segments[2] {
+			writeJSON(w, http.StatusOK, obj)
+			return
+		}
+	}
+	writeNotFound(w, segments[2])
+}
+
+// Serves the space usage of a resource. The fake keeps no data, so the resources use no space.
+func (s *Server) serveSpace(w http.ResponseWriter, selfLink string) {
+	obj := s.objects[selfLink]
+	if obj == nil {
+		writeNotFound(w, selfLink)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"resource":             reference(obj),
+		"total_physical_space": 0,
+		"unique_space":         0,
+		"snapshot_space":       0,
+	})
+}
+
+// Writes the page of items the limit and offset ask for, keeping those the other query parameters match
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, items []object) {
+	query := r.URL.Query()
+	matching := []object{}
+	for _, item := range items {
+		if matchesQuery(item, query) {
+			matching = append(matching, item)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool { return matching[i]["self_link"].(string) < matching[j]["self_link"].(string) })
+
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	if offset > len(matching) {
+		offset = len(matching)
+	}
+	page := matching[offset:]
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit < len(page) {
+		page = page[:limit]
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":                len(page),
+		"more_items_remaining": offset+len(page) < len(matching),
+		"items":                page,
+	})
+}
+
+// Query parameters filter on fields of the items, on the names of the resources they refer to,
+// or with an _id suffix on the IDs of the resources they refer to
+func matchesQuery(item object, query map[string][]string) bool {
+	for param, values := range query {
+		switch param {
+		case "limit", "offset", "sort", "filter":
+			continue
+		}
+
+		field, attribute := param, "name"
+		if _, ok := item[field]; !ok && strings.HasSuffix(param, "_id") {
+			field, attribute = strings.TrimSuffix(param, "_id"), "id"
+		}
+		value, ok := item[field]
+		if !ok {
+			continue
+		}
+		if ref, isRef := value.(object); isRef {
+			value = ref[attribute]
+		}
+		if fmt.Sprint(value) != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) children(collection string) []object {
+	items := []object{}
+	for selfLink, obj := range s.objects {
+		if parentPath(selfLink)+"/"+collectionOf(selfLink) == collection {
+			items = append(items, obj)
+		}
+	}
+	return items
+}
+
+// Returns the IDs in UUID format, counting up so that they are stable within a test
+func (s *Server) newId() string {
+	s.lastId++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012x", s.lastId)
+}
+
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(value); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+func writeError(w http.ResponseWriter, status int, pureCode, format string, args ...interface{}) {
+	writeJSON(w, status, hmrest.ModelError{
+		Message:  fmt.Sprintf(format, args...),
+		PureCode: pureCode,
+		HttpCode: int32(status),
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, name string) {
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "%s not found", name)
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fakefusion
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/antihax/optional"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Waits on the operation started by a request and fails the test unless it succeeds
+func testSucceeded(t *testing.T, client *hmrest.APIClient, op hmrest.Operation, err error) hmrest.Operation {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	succeeded, err := utilities.WaitOnOperation(context.Background(), &op, client.OperationsApi)
+	if err != nil {
+		t.Fatalf("unexpected error waiting on %s: %s", op.RequestType, err)
+	}
+	if !succeeded {
+		t.Fatalf("%s failed: %s", op.RequestType, op.Error_.Message)
+	}
+	return op
+}
+
+// Waits on the operation started by a request and fails the test unless it fails with the given Pure code
+func testFailed(t *testing.T, client *hmrest.APIClient, op hmrest.Operation, err error, pureCode string) hmrest.Operation {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	succeeded, err := utilities.WaitOnOperation(context.Background(), &op, client.OperationsApi)
+	if err != nil {
+		t.Fatalf("unexpected error waiting on %s: %s", op.RequestType, err)
+	}
+	if succeeded || op.Error_.PureCode != pureCode {
+		t.Fatalf("expected %s to fail with %s, got status %s and error %+v", op.RequestType, pureCode, op.Status, op.Error_)
+	}
+	return op
+}
+
+// Creates a Tenant, a Tenant Space, a Storage Service, a Storage Class and a Placement Group for Volumes
+func testPlacementGroup(t *testing.T, client *hmrest.APIClient) {
+	ctx := context.Background()
+
+	op, _, err := client.TenantsApi.CreateTenant(ctx, hmrest.TenantPost{Name: "tenant1"}, nil)
+	testSucceeded(t, client, op, err)
+	op, _, err = client.TenantSpacesApi.CreateTenantSpace(ctx, hmrest.TenantSpacePost{Name: "ts1"}, "tenant1", nil)
+	testSucceeded(t, client, op, err)
+	op, _, err = client.StorageServicesApi.CreateStorageService(ctx, hmrest.StorageServicePost{Name: "ss1", HardwareTypes: []string{"flash-array-x"}}, nil)
+	testSucceeded(t, client, op, err)
+	op, _, err = client.StorageClassesApi.CreateStorageClass(ctx, hmrest.StorageClassPost{Name: "sc1", SizeLimit: 1 << 40}, "ss1", nil)
+	testSucceeded(t, client, op, err)
+	op, _, err = client.PlacementGroupsApi.CreatePlacementGroup(ctx, hmrest.PlacementGroupPost{
+		Name: "pg1", Region: DefaultRegion, AvailabilityZone: DefaultAvailabilityZone, StorageService: "ss1",
+	}, "tenant1", "ts1", nil)
+	testSucceeded(t, client, op, err)
+}
+
+func TestServer_volumeLifecycle(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	client := server.Client()
+	ctx := context.Background()
+
+	testPlacementGroup(t, client)
+	op, _, err := client.VolumesApi.CreateVolume(ctx, hmrest.VolumePost{
+		Name: "vol1", Size: 1048576, StorageClass: "sc1", PlacementGroup: "pg1",
+	}, "tenant1", "ts1", nil)
+	op = testSucceeded(t, client, op, err)
+
+	volume, _, err := client.VolumesApi.GetVolumeById(ctx, op.Result.Resource.Id, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if volume.SelfLink != "/tenants/tenant1/tenant-spaces/ts1/volumes/vol1" || volume.Size != 1048576 || volume.SerialNumber == "" {
+		t.Errorf("unexpected volume %+v", volume)
+	}
+	if volume.Tenant.Name != "tenant1" || volume.TenantSpace.Name != "ts1" || volume.StorageClass.Name != "sc1" ||
+		volume.PlacementGroup.Name != "pg1" || volume.Array.Name != DefaultArray {
+		t.Errorf("unexpected references in volume %+v", volume)
+	}
+
+	// Volumes must be destroyed before they are eradicated
+	op, _, err = client.VolumesApi.DeleteVolume(ctx, "tenant1", "ts1", "vol1", nil)
+	testFailed(t, client, op, err, "FAILED_PRECONDITION")
+
+	// Placement Groups in use cannot be deleted
+	op, _, err = client.PlacementGroupsApi.DeletePlacementGroup(ctx, "tenant1", "ts1", "pg1", nil)
+	testFailed(t, client, op, err, "FAILED_PRECONDITION")
+
+	op, _, err = client.VolumesApi.UpdateVolume(ctx, hmrest.VolumePatch{Destroyed: &hmrest.NullableBoolean{Value: true}}, "tenant1", "ts1", "vol1", nil)
+	testSucceeded(t, client, op, err)
+	op, _, err = client.VolumesApi.DeleteVolume(ctx, "tenant1", "ts1", "vol1", nil)
+	testSucceeded(t, client, op, err)
+	op, _, err = client.PlacementGroupsApi.DeletePlacementGroup(ctx, "tenant1", "ts1", "pg1", nil)
+	testSucceeded(t, client, op, err)
+
+	_, resp, err := client.VolumesApi.GetVolume(ctx, "tenant1", "ts1", "vol1", nil)
+	if err == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected the volume to be gone, got error %v", err)
+	}
+}
+
+func TestServer_snapshotOfPlacementGroup(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	client := server.Client()
+	ctx := context.Background()
+
+	testPlacementGroup(t, client)
+	for _, name := range []string{"vol1", "vol2"} {
+		op, _, err := client.VolumesApi.CreateVolume(ctx, hmrest.VolumePost{
+			Name: name, Size: 1048576, StorageClass: "sc1", PlacementGroup: "pg1",
+		}, "tenant1", "ts1", nil)
+		testSucceeded(t, client, op, err)
+	}
+	op, _, err := client.SnapshotsApi.CreateSnapshot(ctx, hmrest.SnapshotPost{Name: "snap1", PlacementGroup: "pg1"}, "tenant1", "ts1", nil)
+	testSucceeded(t, client, op, err)
+
+	volumeSnapshots, _, err := client.VolumeSnapshotsApi.ListVolumeSnapshots(ctx, "tenant1", "ts1", "snap1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	names := []string{}
+	for _, volumeSnapshot := range volumeSnapshots.Items {
+		names = append(names, volumeSnapshot.Name)
+		if volumeSnapshot.Volume.Name != volumeSnapshot.Name || volumeSnapshot.Size != 1048576 {
+			t.Errorf("unexpected volume snapshot %+v", volumeSnapshot)
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"vol1", "vol2"}) {
+		t.Errorf("expected snapshots of vol1 and vol2, got %v", names)
+	}
+}
+
+func TestServer_operationStatuses(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	server.OperationStatuses = []string{"Pending", "Pending", "Running", "Succeeded"}
+	client := server.Client()
+	ctx := context.Background()
+	seeded := len(server.SelfLinks())
+
+	op, _, err := client.TenantsApi.CreateTenant(ctx, hmrest.TenantPost{Name: "tenant1"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	statuses := []string{op.Status}
+	for op.Status != "Succeeded" && len(statuses) < 10 {
+		if len(server.SelfLinks()) != seeded {
+			t.Errorf("the tenant exists before the operation succeeded")
+		}
+		op, _, err = client.OperationsApi.GetOperation(ctx, op.Id, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		statuses = append(statuses, op.Status)
+	}
+
+	if !reflect.DeepEqual(statuses, server.OperationStatuses) {
+		t.Errorf("expected statuses %v, got %v", server.OperationStatuses, statuses)
+	}
+	if op.Result.Resource.SelfLink != "/tenants/tenant1" || op.RetryIn != 0 {
+		t.Errorf("unexpected operation %+v", op)
+	}
+}
+
+func TestServer_faults(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	server.InjectFault(Fault{Method: http.MethodGet, Path: "/tenants", HttpCode: http.StatusServiceUnavailable, Times: 1})
+	server.InjectFault(Fault{Method: http.MethodPost, Path: "/tenants", PureCode: "EXHAUSTED", Message: "no room"})
+	client := server.Client()
+	ctx := context.Background()
+
+	_, resp, err := client.TenantsApi.ListTenants(ctx, nil)
+	if err == nil || resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the first list to fail with 503, got %v", err)
+	}
+	if _, _, err := client.TenantsApi.ListTenants(ctx, nil); err != nil {
+		t.Errorf("expected the second list to succeed, got %s", err)
+	}
+
+	op, _, err := client.TenantsApi.CreateTenant(ctx, hmrest.TenantPost{Name: "tenant1"}, nil)
+	op = testFailed(t, client, op, err, "EXHAUSTED")
+	if op.Error_.Message != "no room" {
+		t.Errorf("unexpected error message %q", op.Error_.Message)
+	}
+	if _, resp, _ := client.TenantsApi.GetTenant(ctx, "tenant1", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected the failed create to leave nothing behind, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_queries(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	client := server.Client()
+	ctx := context.Background()
+
+	testPlacementGroup(t, client)
+	for _, name := range []string{"vol1", "vol2", "vol3"} {
+		op, _, err := client.VolumesApi.CreateVolume(ctx, hmrest.VolumePost{
+			Name: name, Size: 1048576, StorageClass: "sc1", PlacementGroup: "pg1",
+		}, "tenant1", "ts1", nil)
+		testSucceeded(t, client, op, err)
+	}
+	vol2, _, err := client.VolumesApi.GetVolume(ctx, "tenant1", "ts1", "vol2", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	volumes, _, err := client.VolumesApi.QueryVolumes(ctx, &hmrest.VolumesApiQueryVolumesOpts{SerialNumber: optional.NewString(vol2.SerialNumber)})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(volumes.Items) != 1 || volumes.Items[0].Id != vol2.Id {
+		t.Errorf("expected vol2 by serial number, got %+v", volumes.Items)
+	}
+
+	volumes, _, err = client.VolumesApi.QueryVolumes(ctx, &hmrest.VolumesApiQueryVolumesOpts{
+		PlacementGroupId: optional.NewString(vol2.PlacementGroup.Id), Limit: optional.NewInt32(2), Offset: optional.NewInt32(1),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if volumes.Count != 2 || volumes.MoreItemsRemaining || volumes.Items[0].Name != "vol2" {
+		t.Errorf("expected the last 2 volumes of the placement group, got %+v", volumes)
+	}
+}
+
+func TestServer_space(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	client := server.Client()
+	ctx := context.Background()
+
+	testPlacementGroup(t, client)
+	space, _, err := client.PlacementGroupsApi.GetPlacementGroupsSpace(ctx, "tenant1", "ts1", "pg1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if space.Resource == nil || space.Resource.Name != "pg1" || space.TotalPhysicalSpace != 0 {
+		t.Errorf("expected no space used by pg1, got %+v", space)
+	}
+
+	_, resp, err := client.VolumesApi.GetVolumeSpace(ctx, "tenant1", "ts1", "missing", nil)
+	if err == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing volume, got %v", err)
+	}
+}
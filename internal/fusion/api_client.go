@@ -73,7 +73,7 @@ func (p *apiClientProvider) PrepareCreate(ctx context.Context, d *schema.Resourc
 		PublicKey:   publicKey,
 		DisplayName: displayName,
 	}
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		cl, _, err := client.IdentityManagerApi.CreateApiClient(ctx, *body.(*hmrest.ApiClientPost), nil)
 
 		// TODO: BaseResourceOperation expects operation. ApiClient endpoit does not return operations.
@@ -84,7 +84,7 @@ func (p *apiClientProvider) PrepareCreate(ctx context.Context, d *schema.Resourc
 	return fn, &body, nil
 }
 
-func (p *apiClientProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (p *apiClientProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	ac, _, err := client.IdentityManagerApi.GetApiClientById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -93,8 +93,8 @@ func (p *apiClientProvider) ReadResource(ctx context.Context, client *hmrest.API
 	return p.loadApiClient(ac, d)
 }
 
-func (p *apiClientProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+func (p *apiClientProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		_, _, err := client.IdentityManagerApi.DeleteApiClient(ctx, d.Id(), nil)
 
 		// TODO: BaseResourceOperation expects operation. ApiClient endpoit does not return operations.
@@ -105,7 +105,7 @@ func (p *apiClientProvider) PrepareDelete(ctx context.Context, client *hmrest.AP
 	return fn, nil
 }
 
-func (p *apiClientProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *apiClientProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{resourceGroupNameApiClient}
 	// The ID is user provided value - we expect self link
 	selfLinkFieldsWithValues, err := utilities.ParseSelfLink(d.Id(), orderedRequiredGroupNames)
@@ -21,7 +21,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 // GeneratePublicKey generates a new 2048bit public key in PEM format.
@@ -210,7 +209,7 @@ func testApiClientExists(rName string) resource.TestCheckFunc {
 		}
 		attrs := tfApiClient.Primary.Attributes
 
-		goclientApiClient, _, err := testAccProvider.Meta().(*hmrest.APIClient).IdentityManagerApi.GetApiClientById(context.Background(), attrs["id"], nil)
+		goclientApiClient, _, err := testAccProvider.Meta().(*Client).IdentityManagerApi.GetApiClientById(context.Background(), attrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client returned error while searching for %s by id: %s. Error: %s", attrs["name"], attrs["id"], err)
 		}
@@ -223,7 +222,7 @@ func testApiClientExists(rName string) resource.TestCheckFunc {
 }
 
 func testCheckApiClientDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_api_client" {
@@ -0,0 +1,73 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Asks the control plane for its API version.
+// Failing to get the version is not fatal: returns nil, and the version checks are skipped then.
+func getApiVersion(ctx context.Context, api DefaultAPI) *version.Version {
+	resp, _, err := api.GetVersion(ctx, nil)
+	if err != nil {
+		tflog.Warn(ctx, "cannot get Fusion API version, skipping version checks", "error", err)
+		return nil
+	}
+
+	apiVersion, err := version.NewVersion(resp.Version)
+	if err != nil {
+		tflog.Warn(ctx, "cannot parse Fusion API version, skipping version checks", "version", resp.Version, "error", err)
+		return nil
+	}
+
+	tflog.Info(ctx, "Fusion API version", "version", apiVersion.Original())
+	return apiVersion
+}
+
+// Fails the plan if it sets an attribute which the API version of the control plane does not support yet.
+// Otherwise the API would reject the request only during apply, often with a vague error.
+func checkMinimumApiVersions(minimumApiVersions map[string]string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if len(minimumApiVersions) == 0 {
+			return nil
+		}
+		apiVersion := clientFromMeta(m).apiVersion
+		if apiVersion == nil {
+			return nil
+		}
+
+		// Sorted, so that the same plan always reports the same attribute first
+		attributes := make([]string, 0, len(minimumApiVersions))
+		for attribute := range minimumApiVersions {
+			attributes = append(attributes, attribute)
+		}
+		sort.Strings(attributes)
+
+		for _, attribute := range attributes {
+			// Zero values cannot be told apart from unset attributes, the API ignores them anyway
+			if _, ok := d.GetOk(attribute); !ok || !d.HasChange(attribute) {
+				continue
+			}
+
+			minimumVersion, err := version.NewVersion(minimumApiVersions[attribute])
+			if err != nil {
+				return fmt.Errorf("invalid minimum Fusion API version of %s: %w", attribute, err)
+			}
+			if apiVersion.LessThan(minimumVersion) {
+				return fmt.Errorf("%s requires Fusion API >= %s, but the Fusion API version is %s", attribute, minimumVersion.Original(), apiVersion.Original())
+			}
+		}
+
+		return nil
+	}
+}
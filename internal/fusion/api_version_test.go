@@ -0,0 +1,90 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+)
+
+// Serves GET /info/version with the given version, which the client detects
+func testVersionedClient(t *testing.T, apiVersion string) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(hmrest.Version{Version: apiVersion})
+	}))
+	t.Cleanup(server.Close)
+
+	api := hmrest.NewAPIClient(&hmrest.Configuration{BasePath: server.URL, DefaultHeader: map[string]string{}, HTTPClient: server.Client()})
+	return newConfiguredClient(context.Background(), api)
+}
+
+func testVersionedResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			optionName:           {Type: schema.TypeString, Optional: true},
+			optionPlacementGroup: {Type: schema.TypeString, Optional: true},
+		},
+		CustomizeDiff: checkMinimumApiVersions(map[string]string{optionPlacementGroup: "1.2"}),
+	}
+}
+
+func testVersionedDiff(client *Client, config map[string]interface{}) error {
+	_, err := testVersionedResource().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), client)
+	return err
+}
+
+func TestNewConfiguredClient_apiVersion(t *testing.T) {
+	client := testVersionedClient(t, "1.1")
+
+	if apiVersion := client.apiVersion; apiVersion == nil || apiVersion.Original() != "1.1" {
+		t.Errorf("expected API version 1.1, got %v", apiVersion)
+	}
+}
+
+func TestCheckMinimumApiVersions_olderApiFailsPlan(t *testing.T) {
+	client := testVersionedClient(t, "1.1")
+
+	err := testVersionedDiff(client, map[string]interface{}{optionPlacementGroup: "pg1"})
+	if err == nil || !strings.Contains(err.Error(), "requires Fusion API >= 1.2, but the Fusion API version is 1.1") {
+		t.Errorf("expected the plan to fail on the API version, got %v", err)
+	}
+}
+
+func TestCheckMinimumApiVersions_unsetAttributeIsFine(t *testing.T) {
+	client := testVersionedClient(t, "1.1")
+
+	if err := testVersionedDiff(client, map[string]interface{}{optionName: "vol1"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestCheckMinimumApiVersions_newerApi(t *testing.T) {
+	client := testVersionedClient(t, "1.3")
+
+	if err := testVersionedDiff(client, map[string]interface{}{optionPlacementGroup: "pg1"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestCheckMinimumApiVersions_unknownApiVersion(t *testing.T) {
+	api := hmrest.NewAPIClient(&hmrest.Configuration{BasePath: "http://localhost:0", DefaultHeader: map[string]string{}})
+
+	// The version is unknown when the control plane cannot be asked
+	client := newConfiguredClient(context.Background(), api)
+	if err := testVersionedDiff(client, map[string]interface{}{optionPlacementGroup: "pg1"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -117,13 +117,13 @@ func (p *arrayProvider) PrepareCreate(ctx context.Context, d *schema.ResourceDat
 		ApplianceId:  rdString(ctx, d, optionApplianceId),
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.ArraysApi.CreateArray(ctx, *body.(*hmrest.ArrayPost), region, availabilityZone, nil)
 		if err != nil {
 			return &op, err
 		}
 
-		succeeded, err := utilities.WaitOnOperation(ctx, &op, client)
+		succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
 		if err != nil {
 			return &op, err
 		}
@@ -175,12 +175,12 @@ func (p *arrayProvider) PrepareCreate(ctx context.Context, d *schema.ResourceDat
 				return &op, err
 			}
 
-			succeeded, err := utilities.WaitOnOperation(ctx, &op, client)
+			succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
 			if err != nil {
 				return &op, err
 			}
 			if !succeeded {
-				return &op, fmt.Errorf("operation failed Message:%s ID:%s", op.Error_.Message, op.Id)
+				return &op, utilities.NewRestErrorFromOperation(&op)
 			}
 
 			lastOp = &op
@@ -192,7 +192,7 @@ func (p *arrayProvider) PrepareCreate(ctx context.Context, d *schema.ResourceDat
 	return fn, &body, nil
 }
 
-func (p *arrayProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (p *arrayProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	array, _, err := client.ArraysApi.GetArrayById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -201,7 +201,7 @@ func (p *arrayProvider) ReadResource(ctx context.Context, client *hmrest.APIClie
 	return p.loadArray(array, d)
 }
 
-func (p *arrayProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+func (p *arrayProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
 	if err := utilities.CheckImmutableFieldsExcept(ctx, d,
 		optionDisplayName,
 		optionHostName,
@@ -248,7 +248,7 @@ func (p *arrayProvider) PrepareUpdate(ctx context.Context, client *hmrest.APICli
 		})
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.ArraysApi.UpdateArray(ctx, *body.(*hmrest.ArrayPatch), region, availabilityZone, arrayName, nil)
 		return &op, err
 	}
@@ -256,19 +256,19 @@ func (p *arrayProvider) PrepareUpdate(ctx context.Context, client *hmrest.APICli
 	return fn, patches, nil
 }
 
-func (p *arrayProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (p *arrayProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	arrayName := d.Get(optionName).(string)
 	region := d.Get(optionRegion).(string)
 	availabilityZone := d.Get(optionAvailabilityZone).(string)
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.ArraysApi.DeleteArray(ctx, region, availabilityZone, arrayName, nil)
 		return &op, err
 	}
 	return fn, nil
 }
 
-func (p *arrayProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *arrayProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{
 		resourceGroupNameRegion,
 		resourceGroupNameAvailabilityZone,
@@ -11,7 +11,6 @@ import (
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 type arrayDataSource struct{}
@@ -43,7 +42,7 @@ func dataSourceArray() *schema.Resource {
 	return array.Resource
 }
 
-func (ds *arrayDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *arrayDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	region := d.Get(optionRegion).(string)
 	availabilityZone := d.Get(optionAvailabilityZone).(string)
 	listing, _, err := client.ArraysApi.ListArrays(ctx, region, availabilityZone, nil)
@@ -123,7 +123,7 @@ func poachOneArray(ctx context.Context, hmClient *hmrest.APIClient, arrayIdx int
 
 	tflog.Trace(ctx, "created poaching operation", "region", preexistingRegion, "availability_zone", preexistingAvailabilityZone, "name", src.Name, "operation_id", op.Id)
 
-	if succeeded, err := utilities.WaitOnOperation(ctx, &op, hmClient); err != nil || !succeeded {
+	if succeeded, err := utilities.WaitOnOperation(ctx, &op, hmClient.OperationsApi); err != nil || !succeeded {
 		fail.SetFatal(fmt.Errorf("hmClient.ArraysApi.DeleteArray(%v): wait call error %v / op error %v", src.Name, err, getOperationError(&op)))
 		return
 	}
@@ -188,7 +188,7 @@ func releaseOneArray(ctx context.Context, hmClient *hmrest.APIClient, arrayIdx i
 		return
 	}
 
-	if succeeded, err := utilities.WaitOnOperation(ctx, &op, hmClient); err != nil || !succeeded {
+	if succeeded, err := utilities.WaitOnOperation(ctx, &op, hmClient.OperationsApi); err != nil || !succeeded {
 		fail.SetFatal(fmt.Errorf("hmClient.ArraysApi.CreateArray(%v): wait call error %v / op error %v", *array.Name, err, getOperationError(&op)))
 		return
 	}
@@ -219,7 +219,7 @@ func releaseOneArray(ctx context.Context, hmClient *hmrest.APIClient, arrayIdx i
 			fail.SetFatal(fmt.Errorf("hmClient.ArraysApi.UpdateArray(%v): %v", *array.Name, err))
 			return
 		}
-		if succeeded, err := utilities.WaitOnOperation(ctx, &op, hmClient); err != nil || !succeeded {
+		if succeeded, err := utilities.WaitOnOperation(ctx, &op, hmClient.OperationsApi); err != nil || !succeeded {
 			fail.SetFatal(fmt.Errorf("hmClient.ArraysApi.CreateArray(%v): wait call error %v / op error %v", *array.Name, err, getOperationError(&op)))
 			return
 		}
@@ -347,7 +347,7 @@ func prepareTestingEnvironment(t *testing.T, ctx context.Context, hmClient *hmre
 					t.Fatalf("hmClient.VolumesApi.UpdateVolume(%v, destroyed=true): %v", volume.Name, err)
 				}
 
-				succeeded, err := utilities.WaitOnOperation(ctx, &op, hmClient)
+				succeeded, err := utilities.WaitOnOperation(ctx, &op, hmClient.OperationsApi)
 				if err != nil || !succeeded {
 					t.Fatalf("hmClient.VolumesApi.UpdateVolume(%v, host_access_policies=[]): call error %v / op error %v", volume.Name, err, getOperationError(&op))
 				}
@@ -358,7 +358,7 @@ func prepareTestingEnvironment(t *testing.T, ctx context.Context, hmClient *hmre
 				if err != nil {
 					t.Fatalf("hmClient.VolumesApi.UpdateVolume(%v, destroyed=true): %v", volume.Name, err)
 				}
-				succeeded, err = utilities.WaitOnOperation(ctx, &op, hmClient)
+				succeeded, err = utilities.WaitOnOperation(ctx, &op, hmClient.OperationsApi)
 				if err != nil || !succeeded {
 					t.Fatalf("hmClient.VolumesApi.UpdateVolume(%v, destroyed=true): call error %v / op error %v", volume.Name, err, getOperationError(&op))
 				}
@@ -373,7 +373,7 @@ func prepareTestingEnvironment(t *testing.T, ctx context.Context, hmClient *hmre
 						}
 						t.Fatalf("hmClient.VolumesApi.DeleteVolume(%v, destroyed=true): %v", volume.Name, err)
 					}
-					succeeded, err = utilities.WaitOnOperation(ctx, &op, hmClient)
+					succeeded, err = utilities.WaitOnOperation(ctx, &op, hmClient.OperationsApi)
 					if err != nil || (!succeeded && !isRaceError(&op)) {
 						t.Fatalf("hmClient.VolumesApi.DeleteVolume(%v): wait call error %v / op error %v", volume.Name, err, getOperationError(&op))
 					}
@@ -403,7 +403,7 @@ func prepareTestingEnvironment(t *testing.T, ctx context.Context, hmClient *hmre
 
 				if err == nil && len(snapshots.Items) > 0 {
 					tflog.Info(ctx, "Deleting Snapshots in order to delete Placement Group", "placement_group", placementGroup.Name)
-					deleteSnapshots(ctx, &snapshots, hmClient)
+					deleteSnapshots(ctx, &snapshots, newClient(hmClient))
 				}
 
 				tflog.Debug(ctx, "cleaning up placement group", "name", placementGroup.Name)
@@ -418,7 +418,7 @@ func prepareTestingEnvironment(t *testing.T, ctx context.Context, hmClient *hmre
 						}
 						t.Fatalf("hmClient.PlacementGroupsApi.DeletePlacementGroup(%v): %v", placementGroup.Name, err)
 					}
-					succeeded, err := utilities.WaitOnOperation(ctx, &op, hmClient)
+					succeeded, err := utilities.WaitOnOperation(ctx, &op, hmClient.OperationsApi)
 					if err != nil || (!succeeded && !isRaceError(&op)) {
 						t.Fatalf("hmClient.PlacementGroupsApi.DeletePlacementGroup(%v): wait call error %v / op error %v", placementGroup.Name, err, getOperationError(&op))
 					}
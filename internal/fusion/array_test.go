@@ -17,8 +17,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 /*
@@ -428,7 +426,7 @@ func testArrayExists(rName string, t *testing.T) resource.TestCheckFunc {
 		}
 		savedArray := tfArray.Primary.Attributes
 
-		foundArray, _, err := testAccProvider.Meta().(*hmrest.APIClient).ArraysApi.GetArrayById(context.Background(), savedArray["id"], nil)
+		foundArray, _, err := testAccProvider.Meta().(*Client).ArraysApi.GetArrayById(context.Background(), savedArray["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client returned error while searching for %s by id: %s. Error: %s", savedArray["name"], savedArray["id"], err)
 		}
@@ -448,7 +446,7 @@ func testArrayExists(rName string, t *testing.T) resource.TestCheckFunc {
 }
 
 func testCheckArrayDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_array" {
@@ -65,14 +65,14 @@ func (p *availabilityZoneProvider) PrepareCreate(ctx context.Context, d *schema.
 		DisplayName: displayName,
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.AvailabilityZonesApi.CreateAvailabilityZone(ctx, *body.(*hmrest.AvailabilityZonePost), region, nil)
 		return &op, err
 	}
 	return fn, &body, nil
 }
 
-func (p *availabilityZoneProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (p *availabilityZoneProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	az, _, err := client.AvailabilityZonesApi.GetAvailabilityZoneById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -81,18 +81,18 @@ func (p *availabilityZoneProvider) ReadResource(ctx context.Context, client *hmr
 	return p.loadAZ(az, d)
 }
 
-func (p *availabilityZoneProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (p *availabilityZoneProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	availabilityZoneName := rdString(ctx, d, optionName)
 	region := rdString(ctx, d, optionRegion)
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.AvailabilityZonesApi.DeleteAvailabilityZone(ctx, region, availabilityZoneName, nil)
 		return &op, err
 	}
 	return fn, nil
 }
 
-func (p *availabilityZoneProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *availabilityZoneProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{
 		resourceGroupNameRegion,
 		resourceGroupNameAvailabilityZone,
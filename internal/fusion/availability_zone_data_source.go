@@ -10,7 +10,6 @@ import (
 
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 // Implements DataSource
@@ -41,7 +40,7 @@ func dataSourceAvailabilityZone() *schema.Resource {
 	return availabilityZoneDataSourceFunctions.Resource
 }
 
-func (ds *availabilityZoneDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *availabilityZoneDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	region := d.Get(optionRegion).(string)
 
 	resp, _, err := client.AvailabilityZonesApi.ListAvailabilityZones(ctx, region, nil)
@@ -17,8 +17,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 // Creates and destroys
@@ -189,7 +187,7 @@ func testAvailabilityZoneExists(rName string) resource.TestCheckFunc {
 		}
 		attrs := tfAvailabilityZone.Primary.Attributes
 
-		goclientAvailabilityZone, _, err := testAccProvider.Meta().(*hmrest.APIClient).AvailabilityZonesApi.GetAvailabilityZoneById(context.Background(), attrs["id"], nil)
+		goclientAvailabilityZone, _, err := testAccProvider.Meta().(*Client).AvailabilityZonesApi.GetAvailabilityZoneById(context.Background(), attrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client returned error while searching for %s by id: %s. Error: %s", attrs["name"], attrs["id"], err)
 		}
@@ -203,7 +201,7 @@ func testAvailabilityZoneExists(rName string) resource.TestCheckFunc {
 }
 
 func testCheckAvailabilityZoneDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_availability_zone" {
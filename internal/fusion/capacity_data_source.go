@@ -0,0 +1,111 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+type getSpaceFunc func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Space, *http.Response, error)
+
+// Implements DataSource
+type capacityDataSource struct {
+	getSpace getSpaceFunc
+}
+
+func dataSourceCapacity(resourceKind, displayKind string, identity map[string]*schema.Schema, getSpace getSpaceFunc) *schema.Resource {
+	ds := &capacityDataSource{getSpace: getSpace}
+
+	dsSchema := identity
+	for option, description := range map[string]string{
+		optionTotalPhysicalSpace: "Total physical space occupied by system, shared space, volume, and snapshot data. Measured in bytes.",
+		optionUniqueSpace: "The unique physical space occupied by customer data. Unique physical space does not include shared space, " +
+			"snapshots, and internal array metadata. Measured in bytes.",
+		optionSnapshotSpace: "The sum of total physical space occupied by one or more snapshots associated with the object. Measured in bytes.",
+	} {
+		dsSchema[option] = &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: description,
+		}
+	}
+
+	capacityDataSourceFunctions := NewBaseDataSourceFunctions(resourceKind+"Capacity", ds, dsSchema)
+	capacityDataSourceFunctions.Resource.Description = "Provides the current space usage (total physical, unique and snapshot space) of " + displayKind + "."
+
+	return capacityDataSourceFunctions.Resource
+}
+
+func dataSourceVolumeCapacity() *schema.Resource {
+	return dataSourceCapacity(resourceKindVolume, "a Volume", schemaVolumeMetricsIdentity(),
+		func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Space, *http.Response, error) {
+			return client.VolumesApi.GetVolumeSpace(ctx, rdString(ctx, d, optionTenant), rdString(ctx, d, optionTenantSpace),
+				rdString(ctx, d, optionName), nil)
+		})
+}
+
+func dataSourcePlacementGroupCapacity() *schema.Resource {
+	return dataSourceCapacity(resourceKindPlacementGroup, "a Placement Group", schemaPlacementGroupMetricsIdentity(),
+		func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Space, *http.Response, error) {
+			return client.PlacementGroupsApi.GetPlacementGroupsSpace(ctx, rdString(ctx, d, optionTenant), rdString(ctx, d, optionTenantSpace),
+				rdString(ctx, d, optionName), nil)
+		})
+}
+
+func dataSourceTenantSpaceCapacity() *schema.Resource {
+	return dataSourceCapacity(resourceKindTenantSpace, "a Tenant Space", schemaTenantSpaceMetricsIdentity(),
+		func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Space, *http.Response, error) {
+			return client.TenantSpacesApi.GetTenantSpaceSpace(ctx, rdString(ctx, d, optionTenant), rdString(ctx, d, optionName), nil)
+		})
+}
+
+func dataSourceTenantCapacity() *schema.Resource {
+	return dataSourceCapacity(resourceKindTenant, "a Tenant", schemaTenantMetricsIdentity(),
+		func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Space, *http.Response, error) {
+			return client.TenantsApi.GetTenantsSpace(ctx, rdString(ctx, d, optionName), nil)
+		})
+}
+
+func dataSourceAvailabilityZoneCapacity() *schema.Resource {
+	return dataSourceCapacity(resourceKindAvailabilityZone, "an Availability Zone", schemaAvailabilityZoneMetricsIdentity(),
+		func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Space, *http.Response, error) {
+			return client.AvailabilityZonesApi.GetAvailabilityZoneSpace(ctx, rdString(ctx, d, optionRegion), rdString(ctx, d, optionName), nil)
+		})
+}
+
+func dataSourceArrayCapacity() *schema.Resource {
+	return dataSourceCapacity(resourceKindArray, "an Array", schemaArrayMetricsIdentity(),
+		func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Space, *http.Response, error) {
+			return client.ArraysApi.GetArraySpace(ctx, rdString(ctx, d, optionRegion), rdString(ctx, d, optionAvailabilityZone),
+				rdString(ctx, d, optionName), nil)
+		})
+}
+
+func (ds *capacityDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	space, _, err := ds.getSpace(ctx, client, d)
+	if err != nil {
+		return err
+	}
+
+	err = getFirstError(
+		d.Set(optionTotalPhysicalSpace, space.TotalPhysicalSpace),
+		d.Set(optionUniqueSpace, space.UniqueSpace),
+		d.Set(optionSnapshotSpace, space.SnapshotSpace),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(utilities.GetIdForDataSource())
+
+	return nil
+}
@@ -0,0 +1,451 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Implements DataSource
+type capacityReportDataSource struct{}
+
+// Provisioned size and space usage of a group of volumes
+type capacityUsage struct {
+	volumeCount        int
+	provisionedSize    int64
+	totalPhysicalSpace int64
+	uniqueSpace        int64
+	snapshotSpace      int64
+}
+
+func (u *capacityUsage) addSpace(space hmrest.Space) {
+	u.totalPhysicalSpace += space.TotalPhysicalSpace
+	u.uniqueSpace += space.UniqueSpace
+	u.snapshotSpace += space.SnapshotSpace
+}
+
+func (u *capacityUsage) flatten(result map[string]interface{}) map[string]interface{} {
+	result[optionVolumeCount] = u.volumeCount
+	result[optionProvisionedSize] = u.provisionedSize
+	result[optionTotalPhysicalSpace] = u.totalPhysicalSpace
+	result[optionUniqueSpace] = u.uniqueSpace
+	result[optionSnapshotSpace] = u.snapshotSpace
+	return result
+}
+
+func schemaCapacityUsage(identity map[string]*schema.Schema) map[string]*schema.Schema {
+	result := map[string]*schema.Schema{
+		optionVolumeCount: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of Volumes, destroyed Volumes are not counted.",
+		},
+		optionProvisionedSize: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The sum of the sizes of the Volumes. Measured in bytes.",
+		},
+		optionTotalPhysicalSpace: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Total physical space occupied by system, shared space, volume, and snapshot data. Measured in bytes.",
+		},
+		optionUniqueSpace: {
+			Type:     schema.TypeInt,
+			Computed: true,
+			Description: "The unique physical space occupied by customer data. Unique physical space does not include shared space, " +
+				"snapshots, and internal array metadata. Measured in bytes.",
+		},
+		optionSnapshotSpace: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The sum of total physical space occupied by snapshots. Measured in bytes.",
+		},
+	}
+	for option, optionSchema := range identity {
+		result[option] = optionSchema
+	}
+	return result
+}
+
+type storageClassKey struct{ storageService, storageClass string }
+type placementGroupKey struct{ tenantSpace, name string }
+
+// The number of Volume space requests sent at once
+const volumeSpaceConcurrency = 8
+
+// This is our entry point for the Capacity Report data source
+func dataSourceCapacityReport() *schema.Resource {
+	ds := &capacityReportDataSource{}
+
+	dsSchema := schemaCapacityUsage(map[string]*schema.Schema{
+		optionTenant: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Tenant.",
+		},
+		optionTenantSpace: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Tenant Space. All Tenant Spaces of the Tenant are included if not set.",
+		},
+		optionStorageClasses: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: schemaCapacityUsage(map[string]*schema.Schema{
+					optionStorageService: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					optionStorageClass: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				}),
+			},
+			Description: "Capacity used by the Volumes of each Storage Class.",
+		},
+		optionPlacementGroups: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: schemaCapacityUsage(map[string]*schema.Schema{
+					optionTenantSpace: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					optionName: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				}),
+			},
+			Description: "Capacity used by each Placement Group.",
+		},
+	})
+
+	capacityReportDataSourceFunctions := NewBaseDataSourceFunctions("CapacityReport", ds, dsSchema)
+	capacityReportDataSourceFunctions.Resource.Description = "Aggregates the provisioned size of the Volumes of a Tenant or a Tenant Space " +
+		"against their physical, unique and snapshot space usage, per Storage Class and per Placement Group."
+
+	return capacityReportDataSourceFunctions.Resource
+}
+
+func (ds *capacityReportDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	tenant := rdString(ctx, d, optionTenant)
+
+	var tenantSpaces []string
+	var total capacityUsage
+	tenantSpaceSpaces := map[string]hmrest.Space{}
+	if tenantSpace := rdString(ctx, d, optionTenantSpace); tenantSpace != "" {
+		space, _, err := client.TenantSpacesApi.GetTenantSpaceSpace(ctx, tenant, tenantSpace, nil)
+		if err != nil {
+			return err
+		}
+		total.addSpace(space)
+		tenantSpaceSpaces[tenantSpace] = space
+		tenantSpaces = []string{tenantSpace}
+	} else {
+		space, _, err := client.TenantsApi.GetTenantsSpace(ctx, tenant, nil)
+		if err != nil {
+			return err
+		}
+		total.addSpace(space)
+
+		var opts hmrest.TenantSpacesApiListTenantSpacesOpts
+		_, err = listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+			opts.Limit = optional.NewInt32(limit)
+			opts.Offset = optional.NewInt32(offset)
+			resp, _, err := client.TenantSpacesApi.ListTenantSpaces(ctx, tenant, &opts)
+			if err != nil {
+				return 0, 0, false, err
+			}
+			for _, ts := range resp.Items {
+				tenantSpaces = append(tenantSpaces, ts.Name)
+			}
+			return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	placementGroups := map[placementGroupKey]*capacityUsage{}
+	storageClasses := map[storageClassKey]*capacityUsage{}
+
+	for _, tenantSpace := range tenantSpaces {
+		var pgs []hmrest.PlacementGroup
+		var pgOpts hmrest.PlacementGroupsApiListPlacementGroupsOpts
+		_, err := listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+			pgOpts.Limit = optional.NewInt32(limit)
+			pgOpts.Offset = optional.NewInt32(offset)
+			resp, _, err := client.PlacementGroupsApi.ListPlacementGroups(ctx, tenant, tenantSpace, &pgOpts)
+			if err != nil {
+				return 0, 0, false, err
+			}
+			pgs = append(pgs, resp.Items...)
+			return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+		})
+		if err != nil {
+			return err
+		}
+		placementGroupSpaces := map[string]hmrest.Space{}
+		for _, pg := range pgs {
+			space, _, err := client.PlacementGroupsApi.GetPlacementGroupsSpace(ctx, tenant, tenantSpace, pg.Name, nil)
+			if err != nil {
+				return err
+			}
+			usage := &capacityUsage{}
+			usage.addSpace(space)
+			placementGroups[placementGroupKey{tenantSpace, pg.Name}] = usage
+			placementGroupSpaces[pg.Name] = space
+		}
+
+		var volumes []hmrest.Volume
+		var volumeOpts hmrest.VolumesApiListVolumesOpts
+		_, err = listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+			volumeOpts.Limit = optional.NewInt32(limit)
+			volumeOpts.Offset = optional.NewInt32(offset)
+			resp, _, err := client.VolumesApi.ListVolumes(ctx, tenant, tenantSpace, &volumeOpts)
+			if err != nil {
+				return 0, 0, false, err
+			}
+			volumes = append(volumes, resp.Items...)
+			return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		var liveVolumes []hmrest.Volume
+		for _, volume := range volumes {
+			if volume.Destroyed {
+				continue
+			}
+			liveVolumes = append(liveVolumes, volume)
+
+			total.volumeCount++
+			total.provisionedSize += volume.Size
+
+			if volume.PlacementGroup != nil {
+				if usage, ok := placementGroups[placementGroupKey{tenantSpace, volume.PlacementGroup.Name}]; ok {
+					usage.volumeCount++
+					usage.provisionedSize += volume.Size
+				}
+			}
+		}
+
+		err = addStorageClassesUsage(ctx, client, tenant, tenantSpace, liveVolumes, tenantSpaceSpaces[tenantSpace], placementGroupSpaces, storageClasses)
+		if err != nil {
+			return err
+		}
+	}
+
+	storageClassList := make([]map[string]interface{}, 0, len(storageClasses))
+	for key, usage := range storageClasses {
+		storageClassList = append(storageClassList, usage.flatten(map[string]interface{}{
+			optionStorageService: key.storageService,
+			optionStorageClass:   key.storageClass,
+		}))
+	}
+	sort.Slice(storageClassList, func(i, j int) bool {
+		a, b := storageClassList[i], storageClassList[j]
+		if a[optionStorageService] != b[optionStorageService] {
+			return a[optionStorageService].(string) < b[optionStorageService].(string)
+		}
+		return a[optionStorageClass].(string) < b[optionStorageClass].(string)
+	})
+
+	placementGroupList := make([]map[string]interface{}, 0, len(placementGroups))
+	for key, usage := range placementGroups {
+		placementGroupList = append(placementGroupList, usage.flatten(map[string]interface{}{
+			optionTenantSpace: key.tenantSpace,
+			optionName:        key.name,
+		}))
+	}
+	sort.Slice(placementGroupList, func(i, j int) bool {
+		a, b := placementGroupList[i], placementGroupList[j]
+		if a[optionTenantSpace] != b[optionTenantSpace] {
+			return a[optionTenantSpace].(string) < b[optionTenantSpace].(string)
+		}
+		return a[optionName].(string) < b[optionName].(string)
+	})
+
+	err := getFirstError(
+		d.Set(optionVolumeCount, total.volumeCount),
+		d.Set(optionProvisionedSize, total.provisionedSize),
+		d.Set(optionTotalPhysicalSpace, total.totalPhysicalSpace),
+		d.Set(optionUniqueSpace, total.uniqueSpace),
+		d.Set(optionSnapshotSpace, total.snapshotSpace),
+		d.Set(optionStorageClasses, storageClassList),
+		d.Set(optionPlacementGroups, placementGroupList),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(utilities.GetIdForDataSource())
+
+	return nil
+}
+
+// Adds the provisioned size and the space usage of the Volumes of a Tenant Space to their Storage Classes.
+// Fusion reports the space of Tenant Spaces and Placement Groups, not of Storage Classes. So the space of the Tenant Space
+// is used if all its Volumes are of one Storage Class, else the space of each Placement Group whose Volumes are all of
+// one Storage Class. Only the space of the remaining Volumes is requested Volume by Volume.
+// tenantSpaceSpace is requested if it is the zero value and needed; placementGroupSpaces holds the space of each
+// Placement Group of the Tenant Space.
+func addStorageClassesUsage(
+	ctx context.Context, client *Client, tenant, tenantSpace string, volumes []hmrest.Volume,
+	tenantSpaceSpace hmrest.Space, placementGroupSpaces map[string]hmrest.Space, storageClasses map[storageClassKey]*capacityUsage,
+) error {
+	usageOf := func(key storageClassKey) *capacityUsage {
+		usage, ok := storageClasses[key]
+		if !ok {
+			usage = &capacityUsage{}
+			storageClasses[key] = usage
+		}
+		return usage
+	}
+
+	// The Storage Class of each Volume, nil if unknown, and the Storage Class of each Placement Group, nil if mixed
+	volumeClasses := make([]*storageClassKey, len(volumes))
+	placementGroupClasses := map[string]*storageClassKey{}
+	var tenantSpaceClass *storageClassKey
+	singleClass := true
+	for i, volume := range volumes {
+		key := volumeStorageClass(ctx, volume)
+		volumeClasses[i] = key
+		if key != nil {
+			usage := usageOf(*key)
+			usage.volumeCount++
+			usage.provisionedSize += volume.Size
+		}
+
+		singleClass = singleClass && key != nil && (tenantSpaceClass == nil || *tenantSpaceClass == *key)
+		tenantSpaceClass = key
+		if volume.PlacementGroup != nil {
+			pgClass, seen := placementGroupClasses[volume.PlacementGroup.Name]
+			if !seen || pgClass != nil && key != nil && *pgClass == *key {
+				placementGroupClasses[volume.PlacementGroup.Name] = key
+			} else {
+				placementGroupClasses[volume.PlacementGroup.Name] = nil
+			}
+		}
+	}
+
+	if len(volumes) == 0 {
+		return nil
+	}
+	if singleClass {
+		if tenantSpaceSpace == (hmrest.Space{}) {
+			space, _, err := client.TenantSpacesApi.GetTenantSpaceSpace(ctx, tenant, tenantSpace, nil)
+			if err != nil {
+				return err
+			}
+			tenantSpaceSpace = space
+		}
+		usageOf(*tenantSpaceClass).addSpace(tenantSpaceSpace)
+		return nil
+	}
+
+	for placementGroup, key := range placementGroupClasses {
+		if space, ok := placementGroupSpaces[placementGroup]; ok && key != nil {
+			usageOf(*key).addSpace(space)
+		}
+	}
+
+	var remaining []int
+	for i, volume := range volumes {
+		if volumeClasses[i] == nil {
+			continue
+		}
+		if volume.PlacementGroup != nil && placementGroupClasses[volume.PlacementGroup.Name] != nil {
+			if _, ok := placementGroupSpaces[volume.PlacementGroup.Name]; ok {
+				continue // counted with its Placement Group
+			}
+		}
+		remaining = append(remaining, i)
+	}
+
+	names := make([]string, len(remaining))
+	for j, i := range remaining {
+		names[j] = volumes[i].Name
+	}
+	spaces, err := getVolumeSpaces(ctx, client, tenant, tenantSpace, names)
+	if err != nil {
+		return err
+	}
+	for j, i := range remaining {
+		usageOf(*volumeClasses[i]).addSpace(spaces[j])
+	}
+	return nil
+}
+
+// Returns the Storage Class of the Volume, nil if unknown
+func volumeStorageClass(ctx context.Context, volume hmrest.Volume) *storageClassKey {
+	if volume.StorageClass == nil {
+		return nil
+	}
+	storageClassLink, err := utilities.ParseSelfLink(volume.StorageClass.SelfLink, []string{resourceGroupNameStorageService, resourceGroupNameStorageClass})
+	if err != nil {
+		tflog.Error(ctx, "Skipping volume in capacity report, invalid storage class self link", "volume", volume.Name, "self_link", volume.StorageClass.SelfLink)
+		return nil
+	}
+	return &storageClassKey{storageClassLink[resourceGroupNameStorageService], storageClassLink[resourceGroupNameStorageClass]}
+}
+
+// Gets the space usage of the Volumes, volumeSpaceConcurrency requests at a time. Stops at the first failure.
+func getVolumeSpaces(ctx context.Context, client *Client, tenant, tenantSpace string, volumes []string) ([]hmrest.Space, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	spaces := make([]hmrest.Space, len(volumes))
+	errs := make([]error, len(volumes))
+	semaphore := make(chan struct{}, volumeSpaceConcurrency)
+	var wg sync.WaitGroup
+	for i, volume := range volumes {
+		semaphore <- struct{}{}
+		if ctx.Err() != nil {
+			<-semaphore
+			break
+		}
+		wg.Add(1)
+		go func(i int, volume string) {
+			defer func() { <-semaphore; wg.Done() }()
+			spaces[i], _, errs[i] = client.VolumesApi.GetVolumeSpace(ctx, tenant, tenantSpace, volume, nil)
+			if errs[i] != nil {
+				cancel()
+			}
+		}(i, volume)
+	}
+	wg.Wait()
+
+	// The first failure, not the cancellations it caused
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+	}
+	if err := getFirstError(errs...); err != nil {
+		return nil, err
+	}
+	return spaces, nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Aggregates the only volume of a tenant space, and reads the space data sources along the way
+func TestAccCapacityReportDataSource_basic(t *testing.T) {
+	utilities.CheckTestSkip(t)
+
+	eradicate := true
+	volState, commonConfig := generateVolumeTestConfigAndCommonTFConfig(&eradicate, []string{"flash-array-x"}, nil)
+	size := strconv.Itoa(volState.Size)
+
+	dataSources := fmt.Sprintf(`
+	data "fusion_capacity_report" "report" {
+		tenant       = "%[1]s"
+		tenant_space = "%[2]s"
+		depends_on   = [fusion_volume.%[4]s]
+	}
+
+	data "fusion_volume_capacity" "volume" {
+		tenant       = "%[1]s"
+		tenant_space = "%[2]s"
+		name         = fusion_volume.%[4]s.name
+	}
+
+	data "fusion_tenant_space_capacity" "tenant_space" {
+		tenant = "%[1]s"
+		name   = "%[2]s"
+	}
+
+	data "fusion_tenant_capacity" "tenant" {
+		name = "%[1]s"
+	}
+	`, volState.Tenant, volState.TenantSpace, volState.PlacementGroup, volState.RName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testCheckVolumeDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: commonConfig + testVolumeConfig(volState) + dataSources,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fusion_capacity_report.report", "volume_count", "1"),
+					resource.TestCheckResourceAttr("data.fusion_capacity_report.report", "provisioned_size", size),
+					resource.TestCheckResourceAttrSet("data.fusion_capacity_report.report", "total_physical_space"),
+					resource.TestCheckResourceAttr("data.fusion_capacity_report.report", "storage_classes.#", "1"),
+					resource.TestCheckResourceAttr("data.fusion_capacity_report.report", "storage_classes.0.storage_service", volState.StorageService),
+					resource.TestCheckResourceAttr("data.fusion_capacity_report.report", "storage_classes.0.storage_class", volState.StorageClassName),
+					resource.TestCheckResourceAttr("data.fusion_capacity_report.report", "storage_classes.0.provisioned_size", size),
+					resource.TestCheckResourceAttr("data.fusion_capacity_report.report", "placement_groups.#", "1"),
+					resource.TestCheckResourceAttr("data.fusion_capacity_report.report", "placement_groups.0.name", volState.PlacementGroup),
+					resource.TestCheckResourceAttr("data.fusion_capacity_report.report", "placement_groups.0.volume_count", "1"),
+					resource.TestCheckResourceAttrSet("data.fusion_volume_capacity.volume", "unique_space"),
+					resource.TestCheckResourceAttrSet("data.fusion_tenant_space_capacity.tenant_space", "snapshot_space"),
+					resource.TestCheckResourceAttrSet("data.fusion_tenant_capacity.tenant", "total_physical_space"),
+				),
+			},
+		},
+	})
+}
+
+// Serves the space of each Volume and Tenant Space as 1 byte of unique space, and records which were requested
+type testSpaceApi struct {
+	VolumesAPI
+	TenantSpacesAPI
+	mutex     sync.Mutex
+	requested []string
+}
+
+func (a *testSpaceApi) request(name string) (hmrest.Space, *http.Response, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.requested = append(a.requested, name)
+	return hmrest.Space{UniqueSpace: 1}, nil, nil
+}
+
+func (a *testSpaceApi) GetVolumeSpace(ctx context.Context, tenantName, tenantSpaceName, volumeName string, opts *hmrest.VolumesApiGetVolumeSpaceOpts) (hmrest.Space, *http.Response, error) {
+	return a.request("volume " + volumeName)
+}
+
+func (a *testSpaceApi) GetTenantSpaceSpace(ctx context.Context, tenantName, tenantSpaceName string, opts *hmrest.TenantSpacesApiGetTenantSpaceSpaceOpts) (hmrest.Space, *http.Response, error) {
+	return a.request("tenant space " + tenantSpaceName)
+}
+
+func TestAddStorageClassesUsage(t *testing.T) {
+	volume := func(name, placementGroup, storageClass string) hmrest.Volume {
+		return hmrest.Volume{
+			Name:           name,
+			Size:           10,
+			PlacementGroup: &hmrest.PlacementGroupRef{Name: placementGroup},
+			StorageClass:   &hmrest.StorageClassRef{Name: storageClass, SelfLink: "/storage-services/ss1/storage-classes/" + storageClass},
+		}
+	}
+	placementGroupSpaces := map[string]hmrest.Space{"pg1": {UniqueSpace: 100}, "pg2": {UniqueSpace: 200}}
+
+	tests := []struct {
+		name      string
+		volumes   []hmrest.Volume
+		requested []string
+		expected  map[string]capacityUsage
+	}{
+		{
+			name:      "single storage class",
+			volumes:   []hmrest.Volume{volume("vol1", "pg1", "sc1"), volume("vol2", "pg2", "sc1")},
+			requested: []string{"tenant space ts1"},
+			expected:  map[string]capacityUsage{"sc1": {volumeCount: 2, provisionedSize: 20, uniqueSpace: 1}},
+		},
+		{
+			name: "storage class per placement group",
+			volumes: []hmrest.Volume{
+				volume("vol1", "pg1", "sc1"), volume("vol2", "pg1", "sc1"),
+				volume("vol3", "pg2", "sc1"), volume("vol4", "pg2", "sc2"),
+			},
+			requested: []string{"volume vol3", "volume vol4"},
+			expected: map[string]capacityUsage{
+				"sc1": {volumeCount: 3, provisionedSize: 30, uniqueSpace: 101},
+				"sc2": {volumeCount: 1, provisionedSize: 10, uniqueSpace: 1},
+			},
+		},
+		{name: "no volumes", expected: map[string]capacityUsage{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := &testSpaceApi{}
+			client := &Client{VolumesApi: api, TenantSpacesApi: api}
+			storageClasses := map[storageClassKey]*capacityUsage{}
+
+			err := addStorageClassesUsage(context.Background(), client, "tenant1", "ts1", test.volumes, hmrest.Space{}, placementGroupSpaces, storageClasses)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			sort.Strings(api.requested)
+			if !reflect.DeepEqual(api.requested, test.requested) {
+				t.Errorf("expected requests %v, got %v", test.requested, api.requested)
+			}
+			actual := map[string]capacityUsage{}
+			for key, usage := range storageClasses {
+				actual[key.storageClass] = *usage
+			}
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected usage %+v, got %+v", test.expected, actual)
+			}
+		})
+	}
+}
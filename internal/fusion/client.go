@@ -0,0 +1,284 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/go-version"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Client holds the Fusion API services the resources and data sources call. The services of the generated
+// client implement the interfaces below; unit tests replace the ones they need with fakes, without HTTP.
+// The fields are named as in hmrest.APIClient, so that the calls read the same, e.g., client.VolumesApi.GetVolume.
+type Client struct {
+	ArraysApi                 ArraysAPI
+	AvailabilityZonesApi      AvailabilityZonesAPI
+	DefaultApi                DefaultAPI
+	HardwareTypesApi          HardwareTypesAPI
+	HostAccessPoliciesApi     HostAccessPoliciesAPI
+	IdentityManagerApi        IdentityManagerAPI
+	NetworkInterfaceGroupsApi NetworkInterfaceGroupsAPI
+	NetworkInterfacesApi      NetworkInterfacesAPI
+	OperationsApi             OperationsAPI
+	PlacementGroupsApi        PlacementGroupsAPI
+	ProtectionPoliciesApi     ProtectionPoliciesAPI
+	RegionsApi                RegionsAPI
+	RoleAssignmentsApi        RoleAssignmentsAPI
+	RolesApi                  RolesAPI
+	SnapshotsApi              SnapshotsAPI
+	StorageClassesApi         StorageClassesAPI
+	StorageEndpointsApi       StorageEndpointsAPI
+	StorageServicesApi        StorageServicesAPI
+	TenantSpacesApi           TenantSpacesAPI
+	TenantsApi                TenantsAPI
+	VolumeSnapshotsApi        VolumeSnapshotsAPI
+	VolumesApi                VolumesAPI
+	WorkloadPlannerApi        WorkloadPlannerAPI
+
+	// The API version of the control plane, detected when the provider is configured. nil if not known.
+	apiVersion *version.Version
+}
+
+// Wraps the services of the generated client
+func newClient(api *hmrest.APIClient) *Client {
+	return &Client{
+		ArraysApi:                 api.ArraysApi,
+		AvailabilityZonesApi:      api.AvailabilityZonesApi,
+		DefaultApi:                api.DefaultApi,
+		HardwareTypesApi:          api.HardwareTypesApi,
+		HostAccessPoliciesApi:     api.HostAccessPoliciesApi,
+		IdentityManagerApi:        api.IdentityManagerApi,
+		NetworkInterfaceGroupsApi: api.NetworkInterfaceGroupsApi,
+		NetworkInterfacesApi:      api.NetworkInterfacesApi,
+		OperationsApi:             api.OperationsApi,
+		PlacementGroupsApi:        api.PlacementGroupsApi,
+		ProtectionPoliciesApi:     api.ProtectionPoliciesApi,
+		RegionsApi:                api.RegionsApi,
+		RoleAssignmentsApi:        api.RoleAssignmentsApi,
+		RolesApi:                  api.RolesApi,
+		SnapshotsApi:              api.SnapshotsApi,
+		StorageClassesApi:         api.StorageClassesApi,
+		StorageEndpointsApi:       api.StorageEndpointsApi,
+		StorageServicesApi:        api.StorageServicesApi,
+		TenantSpacesApi:           api.TenantSpacesApi,
+		TenantsApi:                api.TenantsApi,
+		VolumeSnapshotsApi:        api.VolumeSnapshotsApi,
+		VolumesApi:                api.VolumesApi,
+		WorkloadPlannerApi:        api.WorkloadPlannerApi,
+	}
+}
+
+// The provider meta: wraps the services of the generated client, and detects the API version of the control plane
+func newConfiguredClient(ctx context.Context, api *hmrest.APIClient) *Client {
+	client := newClient(api)
+	client.apiVersion = getApiVersion(ctx, client.DefaultApi)
+	return client
+}
+
+// The provider meta is the Client configureProvider returns
+func clientFromMeta(m interface{}) *Client {
+	return m.(*Client)
+}
+
+// The interfaces list the methods of each service the provider and its test sweepers call
+
+type ArraysAPI interface {
+	CreateArray(ctx context.Context, body hmrest.ArrayPost, regionName string, availabilityZoneName string, opts *hmrest.ArraysApiCreateArrayOpts) (hmrest.Operation, *http.Response, error)
+	DeleteArray(ctx context.Context, regionName string, availabilityZoneName string, arrayName string, opts *hmrest.ArraysApiDeleteArrayOpts) (hmrest.Operation, *http.Response, error)
+	GetArray(ctx context.Context, regionName string, availabilityZoneName string, arrayName string, opts *hmrest.ArraysApiGetArrayOpts) (hmrest.Array, *http.Response, error)
+	GetArrayById(ctx context.Context, arrayId string, opts *hmrest.ArraysApiGetArrayByIdOpts) (hmrest.Array, *http.Response, error)
+	GetArrayPerformance(ctx context.Context, regionName string, availabilityZoneName string, arrayName string, opts *hmrest.ArraysApiGetArrayPerformanceOpts) (hmrest.Performance, *http.Response, error)
+	GetArraySpace(ctx context.Context, regionName string, availabilityZoneName string, arrayName string, opts *hmrest.ArraysApiGetArraySpaceOpts) (hmrest.Space, *http.Response, error)
+	ListArrays(ctx context.Context, regionName string, availabilityZoneName string, opts *hmrest.ArraysApiListArraysOpts) (hmrest.ArrayList, *http.Response, error)
+	UpdateArray(ctx context.Context, body hmrest.ArrayPatch, regionName string, availabilityZoneName string, arrayName string, opts *hmrest.ArraysApiUpdateArrayOpts) (hmrest.Operation, *http.Response, error)
+}
+
+type AvailabilityZonesAPI interface {
+	CreateAvailabilityZone(ctx context.Context, body hmrest.AvailabilityZonePost, regionName string, opts *hmrest.AvailabilityZonesApiCreateAvailabilityZoneOpts) (hmrest.Operation, *http.Response, error)
+	DeleteAvailabilityZone(ctx context.Context, regionName string, availabilityZoneName string, opts *hmrest.AvailabilityZonesApiDeleteAvailabilityZoneOpts) (hmrest.Operation, *http.Response, error)
+	GetAvailabilityZone(ctx context.Context, regionName string, availabilityZoneName string, opts *hmrest.AvailabilityZonesApiGetAvailabilityZoneOpts) (hmrest.AvailabilityZone, *http.Response, error)
+	GetAvailabilityZoneById(ctx context.Context, availabilityZoneId string, opts *hmrest.AvailabilityZonesApiGetAvailabilityZoneByIdOpts) (hmrest.AvailabilityZone, *http.Response, error)
+	GetAvailabilityZonePerformance(ctx context.Context, regionName string, availabilityZoneName string, opts *hmrest.AvailabilityZonesApiGetAvailabilityZonePerformanceOpts) (hmrest.Performance, *http.Response, error)
+	GetAvailabilityZoneSpace(ctx context.Context, regionName string, availabilityZoneName string, opts *hmrest.AvailabilityZonesApiGetAvailabilityZoneSpaceOpts) (hmrest.Space, *http.Response, error)
+	ListAvailabilityZones(ctx context.Context, regionName string, opts *hmrest.AvailabilityZonesApiListAvailabilityZonesOpts) (hmrest.AvailabilityZoneList, *http.Response, error)
+}
+
+type DefaultAPI interface {
+	GetVersion(ctx context.Context, opts *hmrest.DefaultApiGetVersionOpts) (hmrest.Version, *http.Response, error)
+}
+
+type HardwareTypesAPI interface {
+	ListHardwareTypes(ctx context.Context, opts *hmrest.HardwareTypesApiListHardwareTypesOpts) (hmrest.HardwareTypeList, *http.Response, error)
+}
+
+type HostAccessPoliciesAPI interface {
+	CreateHostAccessPolicy(ctx context.Context, body hmrest.HostAccessPoliciesPost, opts *hmrest.HostAccessPoliciesApiCreateHostAccessPolicyOpts) (hmrest.Operation, *http.Response, error)
+	DeleteHostAccessPolicy(ctx context.Context, hostAccessPolicyName string, opts *hmrest.HostAccessPoliciesApiDeleteHostAccessPolicyOpts) (hmrest.Operation, *http.Response, error)
+	GetHostAccessPolicy(ctx context.Context, hostAccessPolicyName string, opts *hmrest.HostAccessPoliciesApiGetHostAccessPolicyOpts) (hmrest.HostAccessPolicy, *http.Response, error)
+	GetHostAccessPolicyById(ctx context.Context, hostAccessPolicyId string, opts *hmrest.HostAccessPoliciesApiGetHostAccessPolicyByIdOpts) (hmrest.HostAccessPolicy, *http.Response, error)
+	ListHostAccessPolicies(ctx context.Context, opts *hmrest.HostAccessPoliciesApiListHostAccessPoliciesOpts) (hmrest.HostAccessPolicyList, *http.Response, error)
+}
+
+type IdentityManagerAPI interface {
+	CreateApiClient(ctx context.Context, body hmrest.ApiClientPost, opts *hmrest.IdentityManagerApiCreateApiClientOpts) (hmrest.ApiClient, *http.Response, error)
+	DeleteApiClient(ctx context.Context, apiClientId string, opts *hmrest.IdentityManagerApiDeleteApiClientOpts) (hmrest.ApiClient, *http.Response, error)
+	GetApiClient(ctx context.Context, apiClientId string, opts *hmrest.IdentityManagerApiGetApiClientOpts) (hmrest.ApiClient, *http.Response, error)
+	GetApiClientById(ctx context.Context, apiClientId string, opts *hmrest.IdentityManagerApiGetApiClientByIdOpts) (hmrest.ApiClient, *http.Response, error)
+	ListApiClients(ctx context.Context, opts *hmrest.IdentityManagerApiListApiClientsOpts) ([]hmrest.ApiClient, *http.Response, error)
+	ListUsers(ctx context.Context, opts *hmrest.IdentityManagerApiListUsersOpts) ([]hmrest.User, *http.Response, error)
+}
+
+type NetworkInterfaceGroupsAPI interface {
+	CreateNetworkInterfaceGroup(ctx context.Context, body hmrest.NetworkInterfaceGroupPost, regionName string, availabilityZoneName string, opts *hmrest.NetworkInterfaceGroupsApiCreateNetworkInterfaceGroupOpts) (hmrest.Operation, *http.Response, error)
+	DeleteNetworkInterfaceGroup(ctx context.Context, regionName string, availabilityZoneName string, networkInterfaceGroupName string, opts *hmrest.NetworkInterfaceGroupsApiDeleteNetworkInterfaceGroupOpts) (hmrest.Operation, *http.Response, error)
+	GetNetworkInterfaceGroup(ctx context.Context, regionName string, availabilityZoneName string, networkInterfaceGroupName string, opts *hmrest.NetworkInterfaceGroupsApiGetNetworkInterfaceGroupOpts) (hmrest.NetworkInterfaceGroup, *http.Response, error)
+	GetNetworkInterfaceGroupById(ctx context.Context, networkInterfaceGroupId string, opts *hmrest.NetworkInterfaceGroupsApiGetNetworkInterfaceGroupByIdOpts) (hmrest.NetworkInterfaceGroup, *http.Response, error)
+	ListNetworkInterfaceGroups(ctx context.Context, regionName string, availabilityZoneName string, opts *hmrest.NetworkInterfaceGroupsApiListNetworkInterfaceGroupsOpts) (hmrest.NetworkInterfaceGroupList, *http.Response, error)
+	UpdateNetworkInterfaceGroup(ctx context.Context, body hmrest.NetworkInterfaceGroupPatch, regionName string, availabilityZoneName string, networkInterfaceGroupName string, opts *hmrest.NetworkInterfaceGroupsApiUpdateNetworkInterfaceGroupOpts) (hmrest.Operation, *http.Response, error)
+}
+
+type NetworkInterfacesAPI interface {
+	GetNetworkInterface(ctx context.Context, regionName string, availabilityZoneName string, arrayName string, netIntfName string, opts *hmrest.NetworkInterfacesApiGetNetworkInterfaceOpts) (hmrest.NetworkInterface, *http.Response, error)
+	GetNetworkInterfaceById(ctx context.Context, networkInterfaceId string, opts *hmrest.NetworkInterfacesApiGetNetworkInterfaceByIdOpts) (hmrest.NetworkInterface, *http.Response, error)
+	ListNetworkInterfaces(ctx context.Context, regionName string, availabilityZoneName string, arrayName string, opts *hmrest.NetworkInterfacesApiListNetworkInterfacesOpts) (hmrest.NetworkInterfaceList, *http.Response, error)
+	UpdateNetworkInterface(ctx context.Context, body hmrest.NetworkInterfacePatch, regionName string, availabilityZoneName string, arrayName string, netIntfName string, opts *hmrest.NetworkInterfacesApiUpdateNetworkInterfaceOpts) (hmrest.Operation, *http.Response, error)
+}
+
+type OperationsAPI interface {
+	utilities.OperationsAPI
+	ListOperations(ctx context.Context, opts *hmrest.OperationsApiListOperationsOpts) (hmrest.OperationList, *http.Response, error)
+}
+
+type PlacementGroupsAPI interface {
+	CreatePlacementGroup(ctx context.Context, body hmrest.PlacementGroupPost, tenantName string, tenantSpaceName string, opts *hmrest.PlacementGroupsApiCreatePlacementGroupOpts) (hmrest.Operation, *http.Response, error)
+	DeletePlacementGroup(ctx context.Context, tenantName string, tenantSpaceName string, placementGroupName string, opts *hmrest.PlacementGroupsApiDeletePlacementGroupOpts) (hmrest.Operation, *http.Response, error)
+	GetPlacementGroup(ctx context.Context, tenantName string, tenantSpaceName string, placementGroupName string, opts *hmrest.PlacementGroupsApiGetPlacementGroupOpts) (hmrest.PlacementGroup, *http.Response, error)
+	GetPlacementGroupById(ctx context.Context, placementGroupId string, opts *hmrest.PlacementGroupsApiGetPlacementGroupByIdOpts) (hmrest.PlacementGroup, *http.Response, error)
+	GetPlacementGroupSessions(ctx context.Context, tenantName string, tenantSpaceName string, placementGroupName string, opts *hmrest.PlacementGroupsApiGetPlacementGroupSessionsOpts) (hmrest.SessionList, *http.Response, error)
+	GetPlacementGroupsPerformance(ctx context.Context, tenantName string, tenantSpaceName string, placementGroupName string, opts *hmrest.PlacementGroupsApiGetPlacementGroupsPerformanceOpts) (hmrest.Performance, *http.Response, error)
+	GetPlacementGroupsSpace(ctx context.Context, tenantName string, tenantSpaceName string, placementGroupName string, opts *hmrest.PlacementGroupsApiGetPlacementGroupsSpaceOpts) (hmrest.Space, *http.Response, error)
+	ListPlacementGroups(ctx context.Context, tenantName string, tenantSpaceName string, opts *hmrest.PlacementGroupsApiListPlacementGroupsOpts) (hmrest.PlacementGroupList, *http.Response, error)
+	QueryPlacementGroups(ctx context.Context, opts *hmrest.PlacementGroupsApiQueryPlacementGroupsOpts) (hmrest.PlacementGroupList, *http.Response, error)
+	UpdatePlacementGroup(ctx context.Context, body hmrest.PlacementGroupPatch, tenantName string, tenantSpaceName string, placementGroupName string, opts *hmrest.PlacementGroupsApiUpdatePlacementGroupOpts) (hmrest.Operation, *http.Response, error)
+}
+
+type ProtectionPoliciesAPI interface {
+	CreateProtectionPolicy(ctx context.Context, body hmrest.ProtectionPolicyPost, opts *hmrest.ProtectionPoliciesApiCreateProtectionPolicyOpts) (hmrest.Operation, *http.Response, error)
+	DeleteProtectionPolicy(ctx context.Context, protectionPolicyName string, opts *hmrest.ProtectionPoliciesApiDeleteProtectionPolicyOpts) (hmrest.Operation, *http.Response, error)
+	GetProtectionPolicy(ctx context.Context, protectionPolicyName string, opts *hmrest.ProtectionPoliciesApiGetProtectionPolicyOpts) (hmrest.ProtectionPolicy, *http.Response, error)
+	GetProtectionPolicyById(ctx context.Context, protectionPolicyId string, opts *hmrest.ProtectionPoliciesApiGetProtectionPolicyByIdOpts) (hmrest.ProtectionPolicy, *http.Response, error)
+	ListProtectionPolicies(ctx context.Context, opts *hmrest.ProtectionPoliciesApiListProtectionPoliciesOpts) (hmrest.ProtectionPolicyList, *http.Response, error)
+}
+
+type RegionsAPI interface {
+	CreateRegion(ctx context.Context, body hmrest.RegionPost, opts *hmrest.RegionsApiCreateRegionOpts) (hmrest.Operation, *http.Response, error)
+	DeleteRegion(ctx context.Context, regionName string, opts *hmrest.RegionsApiDeleteRegionOpts) (hmrest.Operation, *http.Response, error)
+	GetRegion(ctx context.Context, regionName string, opts *hmrest.RegionsApiGetRegionOpts) (hmrest.Region, *http.Response, error)
+	GetRegionById(ctx context.Context, regionId string, opts *hmrest.RegionsApiGetRegionByIdOpts) (hmrest.Region, *http.Response, error)
+	ListRegions(ctx context.Context, opts *hmrest.RegionsApiListRegionsOpts) (hmrest.RegionList, *http.Response, error)
+	QueryRegions(ctx context.Context, opts *hmrest.RegionsApiQueryRegionsOpts) (hmrest.RegionList, *http.Response, error)
+	UpdateRegion(ctx context.Context, body hmrest.RegionPatch, regionName string, opts *hmrest.RegionsApiUpdateRegionOpts) (hmrest.Operation, *http.Response, error)
+}
+
+type RoleAssignmentsAPI interface {
+	CreateRoleAssignment(ctx context.Context, body hmrest.RoleAssignmentPost, roleName string, opts *hmrest.RoleAssignmentsApiCreateRoleAssignmentOpts) (hmrest.Operation, *http.Response, error)
+	DeleteRoleAssignment(ctx context.Context, roleName string, roleAssignmentName string, opts *hmrest.RoleAssignmentsApiDeleteRoleAssignmentOpts) (hmrest.Operation, *http.Response, error)
+	GetRoleAssignment(ctx context.Context, roleName string, roleAssignmentName string, opts *hmrest.RoleAssignmentsApiGetRoleAssignmentOpts) (hmrest.RoleAssignment, *http.Response, error)
+	GetRoleAssignmentById(ctx context.Context, roleAssignmentId string, opts *hmrest.RoleAssignmentsApiGetRoleAssignmentByIdOpts) (hmrest.RoleAssignment, *http.Response, error)
+	ListRoleAssignments(ctx context.Context, roleName string, opts *hmrest.RoleAssignmentsApiListRoleAssignmentsOpts) ([]hmrest.RoleAssignment, *http.Response, error)
+}
+
+type RolesAPI interface {
+	ListRoles(ctx context.Context, opts *hmrest.RolesApiListRolesOpts) ([]hmrest.Role, *http.Response, error)
+}
+
+type SnapshotsAPI interface {
+	CreateSnapshot(ctx context.Context, body hmrest.SnapshotPost, tenantName string, tenantSpaceName string, opts *hmrest.SnapshotsApiCreateSnapshotOpts) (hmrest.Operation, *http.Response, error)
+	DeleteSnapshot(ctx context.Context, tenantName string, tenantSpaceName string, snapshotName string, opts *hmrest.SnapshotsApiDeleteSnapshotOpts) (hmrest.Operation, *http.Response, error)
+	GetSnapshot(ctx context.Context, tenantName string, tenantSpaceName string, snapshotName string, opts *hmrest.SnapshotsApiGetSnapshotOpts) (hmrest.Snapshot, *http.Response, error)
+	GetSnapshotById(ctx context.Context, snapshotId string, opts *hmrest.SnapshotsApiGetSnapshotByIdOpts) (hmrest.Snapshot, *http.Response, error)
+	ListSnapshots(ctx context.Context, tenantName string, tenantSpaceName string, opts *hmrest.SnapshotsApiListSnapshotsOpts) (hmrest.SnapshotList, *http.Response, error)
+	QuerySnapshots(ctx context.Context, opts *hmrest.SnapshotsApiQuerySnapshotsOpts) (hmrest.SnapshotList, *http.Response, error)
+	UpdateSnapshot(ctx context.Context, body hmrest.SnapshotPatch, tenantName string, tenantSpaceName string, snapshotName string, opts *hmrest.SnapshotsApiUpdateSnapshotOpts) (hmrest.Operation, *http.Response, error)
+}
+
+type StorageClassesAPI interface {
+	CreateStorageClass(ctx context.Context, body hmrest.StorageClassPost, storageServiceName string, opts *hmrest.StorageClassesApiCreateStorageClassOpts) (hmrest.Operation, *http.Response, error)
+	DeleteStorageClass(ctx context.Context, storageServiceName string, storageClassName string, opts *hmrest.StorageClassesApiDeleteStorageClassOpts) (hmrest.Operation, *http.Response, error)
+	GetStorageClass(ctx context.Context, storageServiceName string, storageClassName string, opts *hmrest.StorageClassesApiGetStorageClassOpts) (hmrest.StorageClass, *http.Response, error)
+	GetStorageClassById(ctx context.Context, storageClassId string, opts *hmrest.StorageClassesApiGetStorageClassByIdOpts) (hmrest.StorageClass, *http.Response, error)
+	ListStorageClasses(ctx context.Context, storageServiceName string, opts *hmrest.StorageClassesApiListStorageClassesOpts) (hmrest.StorageClassList, *http.Response, error)
+	UpdateStorageClass(ctx context.Context, body hmrest.StorageClassPatch, storageServiceName string, storageClassName string, opts *hmrest.StorageClassesApiUpdateStorageClassOpts) (hmrest.Operation, *http.Response, error)
+}
+
+type StorageEndpointsAPI interface {
+	CreateStorageEndpoint(ctx context.Context, body hmrest.StorageEndpointPost, regionName string, availabilityZoneName string, opts *hmrest.StorageEndpointsApiCreateStorageEndpointOpts) (hmrest.Operation, *http.Response, error)
+	DeleteStorageEndpoint(ctx context.Context, regionName string, availabilityZoneName string, storageEndpointName string, opts *hmrest.StorageEndpointsApiDeleteStorageEndpointOpts) (hmrest.Operation, *http.Response, error)
+	GetStorageEndpoint(ctx context.Context, regionName string, availabilityZoneName string, storageEndpointName string, opts *hmrest.StorageEndpointsApiGetStorageEndpointOpts) (hmrest.StorageEndpoint, *http.Response, error)
+	GetStorageEndpointById(ctx context.Context, storageEndpointId string, opts *hmrest.StorageEndpointsApiGetStorageEndpointByIdOpts) (hmrest.StorageEndpoint, *http.Response, error)
+	ListStorageEndpoints(ctx context.Context, regionName string, availabilityZoneName string, opts *hmrest.StorageEndpointsApiListStorageEndpointsOpts) (hmrest.StorageEndpointList, *http.Response, error)
+	UpdateStorageEndpoint(ctx context.Context, body hmrest.StorageEndpointPatch, regionName string, availabilityZoneName string, storageEndpointName string, opts *hmrest.StorageEndpointsApiUpdateStorageEndpointOpts) (hmrest.Operation, *http.Response, error)
+}
+
+type StorageServicesAPI interface {
+	CreateStorageService(ctx context.Context, body hmrest.StorageServicePost, opts *hmrest.StorageServicesApiCreateStorageServiceOpts) (hmrest.Operation, *http.Response, error)
+	DeleteStorageService(ctx context.Context, storageServiceName string, opts *hmrest.StorageServicesApiDeleteStorageServiceOpts) (hmrest.Operation, *http.Response, error)
+	GetStorageService(ctx context.Context, storageServiceName string, opts *hmrest.StorageServicesApiGetStorageServiceOpts) (hmrest.StorageService, *http.Response, error)
+	GetStorageServiceById(ctx context.Context, storageServiceId string, opts *hmrest.StorageServicesApiGetStorageServiceByIdOpts) (hmrest.StorageService, *http.Response, error)
+	ListStorageServices(ctx context.Context, opts *hmrest.StorageServicesApiListStorageServicesOpts) (hmrest.StorageServiceList, *http.Response, error)
+	UpdateStorageService(ctx context.Context, body hmrest.StorageServicePatch, storageServiceName string, opts *hmrest.StorageServicesApiUpdateStorageServiceOpts) (hmrest.Operation, *http.Response, error)
+}
+
+type TenantSpacesAPI interface {
+	CreateTenantSpace(ctx context.Context, body hmrest.TenantSpacePost, tenantName string, opts *hmrest.TenantSpacesApiCreateTenantSpaceOpts) (hmrest.Operation, *http.Response, error)
+	DeleteTenantSpace(ctx context.Context, tenantName string, tenantSpaceName string, opts *hmrest.TenantSpacesApiDeleteTenantSpaceOpts) (hmrest.Operation, *http.Response, error)
+	GetTenantSpace(ctx context.Context, tenantName string, tenantSpaceName string, opts *hmrest.TenantSpacesApiGetTenantSpaceOpts) (hmrest.TenantSpace, *http.Response, error)
+	GetTenantSpaceById(ctx context.Context, tenantSpaceId string, opts *hmrest.TenantSpacesApiGetTenantSpaceByIdOpts) (hmrest.TenantSpace, *http.Response, error)
+	GetTenantSpacePerformance(ctx context.Context, tenantName string, tenantSpaceName string, opts *hmrest.TenantSpacesApiGetTenantSpacePerformanceOpts) (hmrest.Performance, *http.Response, error)
+	GetTenantSpaceSpace(ctx context.Context, tenantName string, tenantSpaceName string, opts *hmrest.TenantSpacesApiGetTenantSpaceSpaceOpts) (hmrest.Space, *http.Response, error)
+	ListTenantSpaces(ctx context.Context, tenantName string, opts *hmrest.TenantSpacesApiListTenantSpacesOpts) (hmrest.TenantSpaceList, *http.Response, error)
+	QueryTenantSpaces(ctx context.Context, opts *hmrest.TenantSpacesApiQueryTenantSpacesOpts) (hmrest.TenantSpaceList, *http.Response, error)
+	UpdateTenantSpace(ctx context.Context, body hmrest.TenantSpacePatch, tenantName string, tenantSpaceName string, opts *hmrest.TenantSpacesApiUpdateTenantSpaceOpts) (hmrest.Operation, *http.Response, error)
+}
+
+type TenantsAPI interface {
+	CreateTenant(ctx context.Context, body hmrest.TenantPost, opts *hmrest.TenantsApiCreateTenantOpts) (hmrest.Operation, *http.Response, error)
+	DeleteTenant(ctx context.Context, tenantName string, opts *hmrest.TenantsApiDeleteTenantOpts) (hmrest.Operation, *http.Response, error)
+	GetTenant(ctx context.Context, tenantName string, opts *hmrest.TenantsApiGetTenantOpts) (hmrest.Tenant, *http.Response, error)
+	GetTenantById(ctx context.Context, tenantId string, opts *hmrest.TenantsApiGetTenantByIdOpts) (hmrest.Tenant, *http.Response, error)
+	GetTenantPerformance(ctx context.Context, tenantName string, opts *hmrest.TenantsApiGetTenantPerformanceOpts) (hmrest.Performance, *http.Response, error)
+	GetTenantsSpace(ctx context.Context, tenantName string, opts *hmrest.TenantsApiGetTenantsSpaceOpts) (hmrest.Space, *http.Response, error)
+	ListTenants(ctx context.Context, opts *hmrest.TenantsApiListTenantsOpts) (hmrest.TenantList, *http.Response, error)
+	QueryTenants(ctx context.Context, opts *hmrest.TenantsApiQueryTenantsOpts) (hmrest.TenantList, *http.Response, error)
+	UpdateTenant(ctx context.Context, body hmrest.TenantPatch, tenantName string, opts *hmrest.TenantsApiUpdateTenantOpts) (hmrest.Operation, *http.Response, error)
+}
+
+type VolumeSnapshotsAPI interface {
+	GetVolumeSnapshot(ctx context.Context, tenantName string, tenantSpaceName string, snapshotName string, volumeSnapshotName string, opts *hmrest.VolumeSnapshotsApiGetVolumeSnapshotOpts) (hmrest.VolumeSnapshot, *http.Response, error)
+	ListVolumeSnapshots(ctx context.Context, tenantName string, tenantSpaceName string, snapshotName string, opts *hmrest.VolumeSnapshotsApiListVolumeSnapshotsOpts) (hmrest.VolumeSnapshotList, *http.Response, error)
+}
+
+type VolumesAPI interface {
+	CreateVolume(ctx context.Context, body hmrest.VolumePost, tenantName string, tenantSpaceName string, opts *hmrest.VolumesApiCreateVolumeOpts) (hmrest.Operation, *http.Response, error)
+	DeleteVolume(ctx context.Context, tenantName string, tenantSpaceName string, volumeName string, opts *hmrest.VolumesApiDeleteVolumeOpts) (hmrest.Operation, *http.Response, error)
+	GetVolume(ctx context.Context, tenantName string, tenantSpaceName string, volumeName string, opts *hmrest.VolumesApiGetVolumeOpts) (hmrest.Volume, *http.Response, error)
+	GetVolumeById(ctx context.Context, volumeId string, opts *hmrest.VolumesApiGetVolumeByIdOpts) (hmrest.Volume, *http.Response, error)
+	GetVolumePerformance(ctx context.Context, tenantName string, tenantSpaceName string, volumeName string, opts *hmrest.VolumesApiGetVolumePerformanceOpts) (hmrest.Performance, *http.Response, error)
+	GetVolumeSpace(ctx context.Context, tenantName string, tenantSpaceName string, volumeName string, opts *hmrest.VolumesApiGetVolumeSpaceOpts) (hmrest.Space, *http.Response, error)
+	ListVolumes(ctx context.Context, tenantName string, tenantSpaceName string, opts *hmrest.VolumesApiListVolumesOpts) (hmrest.VolumeList, *http.Response, error)
+	QueryVolumes(ctx context.Context, opts *hmrest.VolumesApiQueryVolumesOpts) (hmrest.VolumeList, *http.Response, error)
+	UpdateVolume(ctx context.Context, body hmrest.VolumePatch, tenantName string, tenantSpaceName string, volumeName string, opts *hmrest.VolumesApiUpdateVolumeOpts) (hmrest.Operation, *http.Response, error)
+}
+
+type WorkloadPlannerAPI interface {
+	CreatePlacementRecommendation(ctx context.Context, body hmrest.PlacementRecommendationPost, opts *hmrest.WorkloadPlannerApiCreatePlacementRecommendationOpts) (hmrest.Operation, *http.Response, error)
+	GetPlacementRecommendation(ctx context.Context, placementRecommendationName string, opts *hmrest.WorkloadPlannerApiGetPlacementRecommendationOpts) (hmrest.PlacementRecommendation, *http.Response, error)
+}
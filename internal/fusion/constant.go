@@ -96,7 +96,93 @@ const (
 	optionLoadBalancerAddresses             = "load_balancer_addresses"
 	optionFusionConfigProfile               = "fusion_config_profile"
 	optionPrivateKeyPassword                = "private_key_password"
+	optionMaxRetries                        = "max_retries"
+	optionRetryMaxBackoff                   = "retry_max_backoff"
 	optionHardwareTypes                     = "hardware_types"
+	optionVolumes                           = "volumes"
+	optionSimulatedPlacement                = "simulated_placement"
+	optionPlacementEngine                   = "placement_engine"
+	optionTargetArrays                      = "target_arrays"
+	optionIncludedArrays                    = "included_arrays"
+	optionExcludedArrays                    = "excluded_arrays"
+	optionReason                            = "reason"
+	optionDaysToReach90PercentCapacity      = "days_to_reach_90_percent_capacity"
+	optionDaysToReach100PercentCapacity     = "days_to_reach_100_percent_capacity"
+	optionCapacityValues                    = "capacity_values"
+	optionLoadAverage                       = "load_average"
+	optionLoadBlendedMax                    = "load_blended_max"
+	optionObjectives                        = "objectives"
+	optionAvgPerfUsage                      = "avg_perf_usage"
+	optionAvgCapUsage                       = "avg_cap_usage"
+	optionVarPerfUsage                      = "var_perf_usage"
+	optionVarCapUsage                       = "var_cap_usage"
+	optionMaxPerfUsage                      = "max_perf_usage"
+	optionMaxCapUsage                       = "max_cap_usage"
+	optionTimestampMs                       = "timestamp_ms"
+	optionValue                             = "value"
+	optionLower                             = "lower"
+	optionUpper                             = "upper"
+	optionWarnings                          = "warnings"
+	optionMessage                           = "message"
+	optionWarningCode                       = "warning_code"
+	optionError                             = "error"
+	optionArraySelection                    = "array_selection"
+	optionExcludeArrays                     = "exclude_arrays"
+	optionSelectedArray                     = "selected_array"
+	optionPlacementRecommendationId         = "placement_recommendation_id"
+	optionReadsPerSec                       = "reads_per_sec"
+	optionReadLatencyUs                     = "read_latency_us"
+	optionReadBandwidth                     = "read_bandwidth"
+	optionWritesPerSec                      = "writes_per_sec"
+	optionWriteLatencyUs                    = "write_latency_us"
+	optionWriteBandwidth                    = "write_bandwidth"
+	optionTotalPhysicalSpace                = "total_physical_space"
+	optionUniqueSpace                       = "unique_space"
+	optionSnapshotSpace                     = "snapshot_space"
+	optionProvisionedSize                   = "provisioned_size"
+	optionVolumeCount                       = "volume_count"
+	optionStorageClasses                    = "storage_classes"
+	optionPlacementGroups                   = "placement_groups"
+	optionProtocol                          = "protocol"
+	optionInitiatorIqn                      = "initiator_iqn"
+	optionTargetIqn                         = "target_iqn"
+	optionInitiatorPortal                   = "initiator_portal"
+	optionTargetPortal                      = "target_portal"
+	optionTargetDiscoveryAddress            = "target_discovery_address"
+	optionInitiatorIqns                     = "initiator_iqns"
+	optionAction                            = "action"
+	optionRequestType                       = "request_type"
+	optionRequestId                         = "request_id"
+	optionRequestCollection                 = "request_collection"
+	optionResourceKind                      = "resource_kind"
+	optionResourceId                        = "resource_id"
+	optionResourceName                      = "resource_name"
+	optionStatus                            = "status"
+	optionCreatedAfter                      = "created_after"
+	optionStartedAt                         = "started_at"
+	optionEndedAt                           = "ended_at"
+	optionUpdateFields                      = "update_fields"
+	optionPureCode                          = "pure_code"
+	optionHttpCode                          = "http_code"
+	optionDetails                           = "details"
+	optionSort                              = "sort"
+	optionLimit                             = "limit"
+	optionOffset                            = "offset"
+	optionMoreItemsRemaining                = "more_items_remaining"
+	optionVersion                           = "version"
+	optionFilter                            = "filter"
+	optionSnapshotId                        = "snapshot_id"
+	optionTenantSpaceId                     = "tenant_space_id"
+	optionTenantId                          = "tenant_id"
+	optionRegionId                          = "region_id"
+	optionHostAccessPolicy                  = "host_access_policy"
+	optionSource                            = "source"
+	optionSourceVolumeSnapshot              = "source_volume_snapshot"
+	optionWaitForCopy                       = "wait_for_copy"
+	optionRestoreFrom                       = "restore_from"
+	optionTrigger                           = "trigger"
+	optionDeletionProtection                = "deletion_protection"
+	optionForceDestroy                      = "force_destroy"
 )
 
 const (
@@ -117,27 +203,29 @@ const (
 )
 
 const (
-	resourceKindApiClient             = "ApiClient"
-	resourceKindArray                 = "Array"
-	resourceKindAvailabilityZone      = "AvailabilityZone"
-	resourceKindHardwareType          = "HardwareType"
-	resourceKindHostAccessPolicy      = "HostAccessPolicy"
-	resourceKindNetworkInterface      = "NetworkInterface"
-	resourceKindNetworkInterfaceGroup = "NetworkInterfaceGroup"
-	resourceKindPlacementGroup        = "PlacementGroup"
-	resourceKindProtectionPolicy      = "ProtectionPolicy"
-	resourceKindRegion                = "Region"
-	resourceKindRoleAssignment        = "RoleAssignment"
-	resourceKindRole                  = "Role"
-	resourceKindSnapshot              = "Snapshot"
-	resourceKindStorageClass          = "StorageClass"
-	resourceKindStorageEndpoint       = "StorageEndpoint"
-	resourceKindStorageService        = "StorageService"
-	resourceKindTenant                = "Tenant"
-	resourceKindTenantSpace           = "TenantSpace"
-	resourceKindUser                  = "User"
-	resourceKindVolume                = "Volume"
-	resourceKindVolumeSnapshot        = "VolumeSnapshot"
+	resourceKindApiClient               = "ApiClient"
+	resourceKindArray                   = "Array"
+	resourceKindAvailabilityZone        = "AvailabilityZone"
+	resourceKindHardwareType            = "HardwareType"
+	resourceKindHostAccessPolicy        = "HostAccessPolicy"
+	resourceKindNetworkInterface        = "NetworkInterface"
+	resourceKindNetworkInterfaceGroup   = "NetworkInterfaceGroup"
+	resourceKindPlacementGroup          = "PlacementGroup"
+	resourceKindPlacementRecommendation = "PlacementRecommendation"
+	resourceKindProtectionPolicy        = "ProtectionPolicy"
+	resourceKindRegion                  = "Region"
+	resourceKindRoleAssignment          = "RoleAssignment"
+	resourceKindRole                    = "Role"
+	resourceKindSnapshot                = "Snapshot"
+	resourceKindStorageClass            = "StorageClass"
+	resourceKindStorageEndpoint         = "StorageEndpoint"
+	resourceKindStorageService          = "StorageService"
+	resourceKindTenant                  = "Tenant"
+	resourceKindTenantSpace             = "TenantSpace"
+	resourceKindUser                    = "User"
+	resourceKindVolume                  = "Volume"
+	resourceKindVolumeSnapshot          = "VolumeSnapshot"
+	resourceKindVolumeHostAttachment    = "VolumeHostAttachment"
 )
 
 const (
@@ -154,6 +242,7 @@ const (
 	resourceGroupNameProtectionPolicy      = "protection-policies"
 	resourceGroupNameRole                  = "roles"
 	resourceGroupNameRoleAssignment        = "role-assignments"
+	resourceGroupNameSnapshot              = "snapshots"
 	resourceGroupNameStorageService        = "storage-services"
 	resourceGroupNameStorageClass          = "storage-classes"
 	resourceGroupNameStorageEndpoint       = "storage-endpoints"
@@ -9,7 +9,6 @@ import (
 	"context"
 
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -18,7 +17,7 @@ import (
 
 type DataSource interface {
 	// Synchronously reads the data source via its REST API.
-	ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (err error)
+	ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) (err error)
 }
 
 type BaseDataSourceFunctions struct {
@@ -41,17 +40,18 @@ func NewBaseDataSourceFunctions(resourceKind string, dataSource DataSource, dsSc
 }
 
 func (f *BaseDataSourceFunctions) dataSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client, _ := f.dataSourceBoilerplate(ctx, "Read", d, m)
+	client, ctx := f.dataSourceBoilerplate(ctx, "Read", d, m)
 	err := f.DataSource.ReadDataSource(ctx, client, d)
 	return utilities.ProcessClientError(ctx, "read", err)
 }
 
 // A function used at the top of the datasource READ function to grab stuff we need.
-func (f *BaseDataSourceFunctions) dataSourceBoilerplate(ctx context.Context, action string, d *schema.ResourceData, m interface{}) (*hmrest.APIClient, context.Context) {
+func (f *BaseDataSourceFunctions) dataSourceBoilerplate(ctx context.Context, action string, d *schema.ResourceData, m interface{}) (*Client, context.Context) {
 	ctx = tflog.With(ctx, "datasource_kind", f.DataSourceKind)
+	ctx = utilities.WithRequestIdRecorder(ctx)
 	tflog.Debug(ctx, "datasource", "action", action, "state", d.State())
 
-	client := m.(*hmrest.APIClient)
+	client := clientFromMeta(m)
 
 	return client, ctx
 }
@@ -0,0 +1,47 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+func TestDataSourceRead_reportsRequestId(t *testing.T) {
+	var requestId string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId = r.Header.Get("X-Request-ID")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(hmrest.ErrorResponse{Error_: &hmrest.ModelError{
+			Message:  "not allowed to list tenants",
+			PureCode: "PERMISSION_DENIED",
+			HttpCode: http.StatusForbidden,
+		}})
+	}))
+	t.Cleanup(server.Close)
+	api, err := NewHMClientWithAccessToken(context.Background(), server.URL, "access-token",
+		utilities.NewRetryTransport(server.Client().Transport, 0, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := dataSourceTenant()
+	diags := r.ReadContext(context.Background(), r.TestResourceData(), newClient(api))
+	if len(diags) != 1 || requestId == "" {
+		t.Fatalf("expected a single error for a request with an ID, got %v (request ID %q)", diags, requestId)
+	}
+	if expected := "Pure code: PERMISSION_DENIED\nHTTP code: 403\nRequest ID: " + requestId; diags[0].Detail != expected {
+		t.Errorf("expected detail %q, got %q", expected, diags[0].Detail)
+	}
+}
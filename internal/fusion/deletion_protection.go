@@ -0,0 +1,57 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resources holding data opt into deletion protection by adding this attribute to their schema.
+// BaseResourceFunctions then refuses to delete or replace them while it is set in the state.
+func schemaDeletionProtection(items string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "Prevents Terraform from deleting or replacing the " + items + ". " +
+			"Set it to `false` and apply before destroying the " + items + ".",
+	}
+}
+
+// Returns true if the resource supports deletion protection and the state has it enabled
+func (f *BaseResourceFunctions) deletionProtected(d *schema.ResourceData) bool {
+	if _, ok := f.Resource.Schema[optionDeletionProtection]; !ok {
+		return false
+	}
+	return d.Get(optionDeletionProtection).(bool)
+}
+
+// Fails plans replacing a resource which has deletion protection enabled in the state,
+// i.e. plans changing one of its ForceNew attributes. Destroy plans do not run CustomizeDiff, resourceDelete refuses those.
+func (f *BaseResourceFunctions) checkDeletionProtection(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if _, ok := f.Resource.Schema[optionDeletionProtection]; !ok || d.Id() == "" {
+		return nil
+	}
+	if protected, _ := d.GetChange(optionDeletionProtection); !protected.(bool) {
+		return nil
+	}
+
+	changedKeys := d.GetChangedKeysPrefix("")
+	sort.Strings(changedKeys)
+	for _, changedKey := range changedKeys {
+		key := strings.SplitN(changedKey, ".", 2)[0]
+		if keySchema, ok := f.Resource.Schema[key]; ok && keySchema.ForceNew {
+			return fmt.Errorf("%s %s has deletion_protection enabled, changing %s would replace it. "+
+				"Set deletion_protection = false and apply before replacing it", f.ResourceKind, d.Get(optionName), key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+)
+
+func TestResourceDelete_deletionProtection(t *testing.T) {
+	// Any request would fail, the delete must stop before calling the API
+	client := newClient(hmrest.NewAPIClient(&hmrest.Configuration{BasePath: "http://127.0.0.1:0", DefaultHeader: map[string]string{}}))
+
+	for _, test := range []struct {
+		kind     string
+		resource *schema.Resource
+	}{
+		{"Volume", resourceVolume()},
+		{"TenantSpace", resourceTenantSpace()},
+		{"PlacementGroup", resourcePlacementGroup()},
+	} {
+		t.Run(test.kind, func(t *testing.T) {
+			d := test.resource.TestResourceData()
+			d.SetId("resource-id")
+			d.Set(optionName, "protected")
+			d.Set(optionDeletionProtection, true)
+
+			diags := test.resource.DeleteContext(context.Background(), d, client)
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, "cannot delete "+test.kind+" protected: deletion_protection is enabled") {
+				t.Fatalf("expected deletion protection error, got %v", diags)
+			}
+		})
+	}
+}
+
+func TestCheckDeletionProtection_replacement(t *testing.T) {
+	r := resourcePlacementGroup()
+	// Placement groups are updated in place, have the storage service force their replacement
+	r.Schema[optionStorageService].ForceNew = true
+
+	placementGroup := func(storageService, displayName string, protected bool) map[string]interface{} {
+		return map[string]interface{}{
+			optionName:               "pg1",
+			optionDisplayName:        displayName,
+			optionTenant:             "tenant1",
+			optionTenantSpace:        "tenant-space1",
+			optionRegion:             "region1",
+			optionAvailabilityZone:   "az1",
+			optionStorageService:     storageService,
+			optionDeletionProtection: protected,
+		}
+	}
+
+	for _, test := range []struct {
+		name        string
+		protected   bool
+		newRaw      map[string]interface{}
+		expectError bool
+	}{
+		{"protected replacement", true, placementGroup("ss2", "pg1", true), true},
+		{"protection turned off with the replacement", true, placementGroup("ss2", "pg1", false), true},
+		{"protected update", true, placementGroup("ss1", "new", true), false},
+		{"unprotected replacement", false, placementGroup("ss2", "pg1", false), false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			old := schema.TestResourceDataRaw(t, r.Schema, placementGroup("ss1", "pg1", test.protected))
+			old.SetId("pg-id")
+
+			diff, err := schema.InternalMap(r.Schema).Diff(context.Background(), old.State(),
+				terraform.NewResourceConfigRaw(test.newRaw), r.CustomizeDiff, &Client{}, true)
+			if test.expectError != (err != nil) {
+				t.Fatalf("expected error %t, got %v", test.expectError, err)
+			}
+			if err != nil && !strings.Contains(err.Error(), "changing storage_service would replace it") {
+				t.Errorf("expected the error to name the replacing change, got %v", err)
+			}
+			if err == nil && diff.RequiresNew() != (test.newRaw[optionStorageService] != "ss1") {
+				t.Errorf("expected replacement %t, got %t", test.newRaw[optionStorageService] != "ss1", diff.RequiresNew())
+			}
+		})
+	}
+}
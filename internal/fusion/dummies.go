@@ -26,12 +26,12 @@ func MakeDummyCreateOperation(resourceId string) *hmrest.Operation {
 	}
 }
 
-func DummyInvokeWriteAPI(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (operation *hmrest.Operation, err error) {
+func DummyInvokeWriteAPI(ctx context.Context, client *Client, body RequestSpec) (operation *hmrest.Operation, err error) {
 	return &DummyOperation, nil
 }
 
 func MakeDummyCreateInvokeWriteAPI(resourceId string) InvokeWriteAPI {
-	return func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (operation *hmrest.Operation, err error) {
+	return func(ctx context.Context, client *Client, body RequestSpec) (operation *hmrest.Operation, err error) {
 		return MakeDummyCreateOperation(resourceId), nil
 	}
 }
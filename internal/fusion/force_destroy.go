@@ -0,0 +1,169 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/antihax/optional"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+func schemaForceDestroy(items, children string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "Delete all the " + children + " in the " + items + " when the " + items + " is deleted, " +
+			"even those Terraform does not manage. Without it, the " + items + " can only be deleted once it is empty. " +
+			"It overrides the `deletion_protection` of those resources: they are deleted even when they have it enabled. " +
+			"WARNING: The Volumes are eradicated and cannot be recovered.",
+	}
+}
+
+// Deletes all the Tenant Spaces of the Tenant along with their contents, so that the Tenant can be deleted.
+// Keeps going after a Tenant Space fails and returns all the failures.
+// deletion_protection lives in the Terraform state only, so the cascade does not see nor honour it.
+func emptyTenant(ctx context.Context, client *Client, tenant string) error {
+	var tenantSpaces []hmrest.TenantSpace
+	_, err := listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		resp, _, err := client.TenantSpacesApi.ListTenantSpaces(ctx, tenant, &hmrest.TenantSpacesApiListTenantSpacesOpts{
+			Limit:  optional.NewInt32(limit),
+			Offset: optional.NewInt32(offset),
+		})
+		if err != nil {
+			return 0, 0, false, err
+		}
+		tenantSpaces = append(tenantSpaces, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing tenant spaces of tenant %s: %w", tenant, err)
+	}
+
+	var errs *multierror.Error
+	for _, ts := range tenantSpaces {
+		tflog.Info(ctx, "force destroying tenant space", "tenant", tenant, "tenant_space", ts.Name)
+		if err := emptyTenantSpace(ctx, client, tenant, ts.Name); err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		op, _, err := client.TenantSpacesApi.DeleteTenantSpace(ctx, tenant, ts.Name, nil)
+		if err := waitOnForceDestroy(ctx, client, &op, err, "tenant space", ts.Name); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// Deletes the contents of the Tenant Space in dependency order, so that the Tenant Space can be deleted:
+// eradicates the Volumes, then deletes the Snapshots and finally the Placement Groups.
+// Keeps going after an item fails, but does not start the next kind of items. Returns all the failures.
+// Like emptyTenant, it deletes the items regardless of their deletion_protection.
+func emptyTenantSpace(ctx context.Context, client *Client, tenant, tenantSpace string) error {
+	var volumes []hmrest.Volume
+	_, err := listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		resp, _, err := client.VolumesApi.ListVolumes(ctx, tenant, tenantSpace, &hmrest.VolumesApiListVolumesOpts{
+			Limit:  optional.NewInt32(limit),
+			Offset: optional.NewInt32(offset),
+		})
+		if err != nil {
+			return 0, 0, false, err
+		}
+		volumes = append(volumes, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing volumes of tenant space %s: %w", tenantSpace, err)
+	}
+
+	var errs *multierror.Error
+	for _, vol := range volumes {
+		tflog.Debug(ctx, "force destroying volume", "tenant_space", tenantSpace, "volume", vol.Name, "destroyed", vol.Destroyed)
+		op, err := eradicateVolume(ctx, client, tenant, tenantSpace, vol)
+		if err := waitOnForceDestroy(ctx, client, op, err, "volume", vol.Name); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+
+	var snapshots []hmrest.Snapshot
+	_, err = listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		resp, _, err := client.SnapshotsApi.ListSnapshots(ctx, tenant, tenantSpace, &hmrest.SnapshotsApiListSnapshotsOpts{
+			Limit:  optional.NewInt32(limit),
+			Offset: optional.NewInt32(offset),
+		})
+		if err != nil {
+			return 0, 0, false, err
+		}
+		snapshots = append(snapshots, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing snapshots of tenant space %s: %w", tenantSpace, err)
+	}
+	if err := deleteSnapshots(ctx, &hmrest.SnapshotList{Items: snapshots}, client); err != nil {
+		return err
+	}
+
+	var placementGroups []hmrest.PlacementGroup
+	_, err = listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		resp, _, err := client.PlacementGroupsApi.ListPlacementGroups(ctx, tenant, tenantSpace, &hmrest.PlacementGroupsApiListPlacementGroupsOpts{
+			Limit:  optional.NewInt32(limit),
+			Offset: optional.NewInt32(offset),
+		})
+		if err != nil {
+			return 0, 0, false, err
+		}
+		placementGroups = append(placementGroups, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing placement groups of tenant space %s: %w", tenantSpace, err)
+	}
+
+	for _, pg := range placementGroups {
+		tflog.Debug(ctx, "force destroying placement group", "tenant_space", tenantSpace, "placement_group", pg.Name)
+		op, _, err := client.PlacementGroupsApi.DeletePlacementGroup(ctx, tenant, tenantSpace, pg.Name, nil)
+		if err := waitOnForceDestroy(ctx, client, &op, err, "placement group", pg.Name); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// Starts eradicating the Volume, destroying it first unless it already is
+func eradicateVolume(ctx context.Context, client *Client, tenant, tenantSpace string, vol hmrest.Volume) (*hmrest.Operation, error) {
+	if vol.Destroyed {
+		// Already destroyed, only the eradication is left
+		op, _, err := client.VolumesApi.DeleteVolume(ctx, tenant, tenantSpace, vol.Name, nil)
+		return &op, err
+	}
+	return deleteVolume(ctx, client, tenant, tenantSpace, vol.Name, true)
+}
+
+// Waits for the operation deleting an item, the error tells which item failed
+func waitOnForceDestroy(ctx context.Context, client *Client, op *hmrest.Operation, err error, kind, name string) error {
+	if err == nil {
+		var succeeded bool
+		succeeded, err = utilities.WaitOnOperation(ctx, op, client.OperationsApi)
+		if err == nil && !succeeded {
+			err = utilities.NewRestErrorFromOperation(op)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("deleting %s %s: %w", kind, name, err)
+	}
+	return nil
+}
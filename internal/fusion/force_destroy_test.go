@@ -0,0 +1,150 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/fakefusion"
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+)
+
+// Serves a Tenant Space holding a Volume, a destroyed Volume, a Snapshot and a Placement Group.
+// Records the changes made to them; the changes of failPath fail.
+func testTenantSpaceContentsClient(t *testing.T, failPath string) (*Client, *[]string) {
+	changes := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		tenantRef := &hmrest.TenantRef{Name: "tenant1"}
+		tenantSpaceRef := &hmrest.TenantSpaceRef{Name: "ts1"}
+
+		if r.Method != http.MethodGet {
+			changes = append(changes, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/tenants/tenant1/tenant-spaces/ts1"))
+			op := hmrest.Operation{Id: "op-id", Status: "Succeeded"}
+			if r.URL.Path == failPath {
+				op.Status = "Failed"
+				op.Error_ = &hmrest.ModelError{Message: "still in use"}
+			}
+			json.NewEncoder(w).Encode(op)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/tenants/tenant1/tenant-spaces/ts1/volumes":
+			json.NewEncoder(w).Encode(hmrest.VolumeList{Count: 2, Items: []hmrest.Volume{
+				{Name: "vol1", Tenant: tenantRef, TenantSpace: tenantSpaceRef},
+				{Name: "vol2", Tenant: tenantRef, TenantSpace: tenantSpaceRef, Destroyed: true},
+			}})
+		case "/tenants/tenant1/tenant-spaces/ts1/snapshots":
+			json.NewEncoder(w).Encode(hmrest.SnapshotList{Count: 1, Items: []hmrest.Snapshot{
+				{Name: "snap1", Tenant: tenantRef, TenantSpace: tenantSpaceRef},
+			}})
+		case "/tenants/tenant1/tenant-spaces/ts1/placement-groups":
+			json.NewEncoder(w).Encode(hmrest.PlacementGroupList{Count: 1, Items: []hmrest.PlacementGroup{
+				{Name: "pg1", Tenant: tenantRef, TenantSpace: tenantSpaceRef},
+			}})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client := newClient(hmrest.NewAPIClient(&hmrest.Configuration{BasePath: server.URL, DefaultHeader: map[string]string{}, HTTPClient: server.Client()}))
+	return client, &changes
+}
+
+func TestEmptyTenantSpace_dependencyOrder(t *testing.T) {
+	client, changes := testTenantSpaceContentsClient(t, "")
+
+	if err := emptyTenantSpace(context.Background(), client, "tenant1", "ts1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"PATCH /volumes/vol1", // disconnect the hosts
+		"PATCH /volumes/vol1", // destroy
+		"DELETE /volumes/vol1",
+		"DELETE /volumes/vol2",
+		"PATCH /snapshots/snap1",
+		"DELETE /snapshots/snap1",
+		"DELETE /placement-groups/pg1",
+	}
+	if !reflect.DeepEqual(*changes, expected) {
+		t.Errorf("expected changes %v, got %v", expected, *changes)
+	}
+}
+
+func TestEmptyTenantSpace_failedVolume(t *testing.T) {
+	client, changes := testTenantSpaceContentsClient(t, "/tenants/tenant1/tenant-spaces/ts1/volumes/vol1")
+
+	err := emptyTenantSpace(context.Background(), client, "tenant1", "ts1")
+	if err == nil || !strings.Contains(err.Error(), "deleting volume vol1") {
+		t.Fatalf("expected the volume failure, got %v", err)
+	}
+
+	// The other volume is still deleted, the snapshots and placement groups are left alone
+	expected := []string{
+		"PATCH /volumes/vol1",
+		"DELETE /volumes/vol2",
+	}
+	if !reflect.DeepEqual(*changes, expected) {
+		t.Errorf("expected changes %v, got %v", expected, *changes)
+	}
+}
+
+func TestTenantForceDestroy_fakeApi(t *testing.T) {
+	server := fakefusion.NewServer()
+	t.Cleanup(server.Close)
+	client := newClient(server.Client())
+	ctx := context.Background()
+	seeded := server.SelfLinks()
+
+	tenant := resourceTenant()
+	d := tenant.TestResourceData()
+	d.Set(optionName, "tenant1")
+	d.Set(optionForceDestroy, true)
+	if diags := tenant.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	for _, body := range []struct {
+		collection string
+		fields     map[string]interface{}
+	}{
+		{"/tenants/tenant1/tenant-spaces", map[string]interface{}{"name": "ts1"}},
+		{"/storage-services", map[string]interface{}{"name": "ss1", "hardware_types": []interface{}{"flash-array-x"}}},
+		{"/storage-services/ss1/storage-classes", map[string]interface{}{"name": "sc1"}},
+		{"/tenants/tenant1/tenant-spaces/ts1/placement-groups", map[string]interface{}{
+			"name": "pg1", "region": fakefusion.DefaultRegion, "availability_zone": fakefusion.DefaultAvailabilityZone, "storage_service": "ss1",
+		}},
+		{"/tenants/tenant1/tenant-spaces/ts1/volumes", map[string]interface{}{
+			"name": "vol1", "size": 1048576, "storage_class": "sc1", "placement_group": "pg1",
+		}},
+		{"/tenants/tenant1/tenant-spaces/ts1/snapshots", map[string]interface{}{"name": "snap1", "placement_group": "pg1"}},
+	} {
+		if err := server.Seed(body.collection, body.fields); err != nil {
+			t.Fatalf("cannot seed %s: %s", body.collection, err)
+		}
+	}
+
+	if diags := tenant.DeleteContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// Only the Storage Service and Class outlive the Tenant
+	expected := append(seeded, "/storage-services/ss1", "/storage-services/ss1/storage-classes/sc1")
+	sort.Strings(expected)
+	if got := server.SelfLinks(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v to remain, got %v", expected, got)
+	}
+}
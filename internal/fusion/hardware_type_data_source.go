@@ -74,7 +74,7 @@ func dataSourceHardwareType() *schema.Resource {
 	return hardwareTypeDataSourceFunctions.Resource
 }
 
-func (ds *hardwareTypeDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *hardwareTypeDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	options := hmrest.HardwareTypesApiListHardwareTypesOpts{}
 
 	options.MediaType = optional.NewString(rdString(ctx, d, optionMediaType))
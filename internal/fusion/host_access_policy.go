@@ -74,14 +74,14 @@ func (p *hostAccessPolicyProvider) PrepareCreate(ctx context.Context, d *schema.
 		Personality: personality,
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.HostAccessPoliciesApi.CreateHostAccessPolicy(ctx, *body.(*hmrest.HostAccessPoliciesPost), nil)
 		return &op, err
 	}
 	return fn, &body, nil
 }
 
-func (p *hostAccessPolicyProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (p *hostAccessPolicyProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	hap, _, err := client.HostAccessPoliciesApi.GetHostAccessPolicyById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -90,17 +90,17 @@ func (p *hostAccessPolicyProvider) ReadResource(ctx context.Context, client *hmr
 	return p.loadHAP(hap, d)
 }
 
-func (p *hostAccessPolicyProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (p *hostAccessPolicyProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	hostAccessPolicyName := rdString(ctx, d, optionName)
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.HostAccessPoliciesApi.DeleteHostAccessPolicy(ctx, hostAccessPolicyName, nil)
 		return &op, err
 	}
 	return fn, nil
 }
 
-func (p *hostAccessPolicyProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *hostAccessPolicyProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{resourceGroupNameHostAccessPolicy}
 	// The ID is user provided value - we expect self link
 	selfLinkFieldsWithValues, err := utilities.ParseSelfLink(d.Id(), orderedRequiredGroupNames)
@@ -10,7 +10,6 @@ import (
 
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 type hostAccessPolicyDataSource struct{}
@@ -35,7 +34,7 @@ func dataSourceHostAccessPolicy() *schema.Resource {
 	return hostAccessPolicyDataSourceFunctions.Resource
 }
 
-func (ds *hostAccessPolicyDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (err error) {
+func (ds *hostAccessPolicyDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) (err error) {
 	resp, _, err := client.HostAccessPoliciesApi.ListHostAccessPolicies(ctx, nil)
 	if err != nil {
 		return err
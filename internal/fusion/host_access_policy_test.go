@@ -17,7 +17,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 func TestAccHostAccessPolicy_basic(t *testing.T) {
@@ -145,7 +144,7 @@ func testHostAccessPolicyExists(rName string) resource.TestCheckFunc {
 		}
 		attrs := tfHostAccessPolicy.Primary.Attributes
 
-		goclientHostAccessPolicy, _, err := testAccProvider.Meta().(*hmrest.APIClient).HostAccessPoliciesApi.GetHostAccessPolicyById(context.Background(), attrs["id"], nil)
+		goclientHostAccessPolicy, _, err := testAccProvider.Meta().(*Client).HostAccessPoliciesApi.GetHostAccessPolicyById(context.Background(), attrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("Go client returned error while searching for %s by id: %s. Error: %s", attrs["name"], attrs["id"], err)
 		}
@@ -160,7 +159,7 @@ func testHostAccessPolicyExists(rName string) resource.TestCheckFunc {
 }
 
 func testCheckHAPDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_host_access_policy" {
 			continue
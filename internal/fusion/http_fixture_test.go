@@ -0,0 +1,155 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/httpfixture"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Set to record to save the Fusion traffic of the acceptance tests calling testAccHttpFixture, or to replay
+// to run these tests offline against the saved traffic. The other acceptance tests are skipped when replaying.
+const varHttpFixtureMode = "FUSION_HTTP_FIXTURE_MODE"
+
+// The file the traffic is saved to and replayed from
+const varHttpFixturePath = "FUSION_HTTP_FIXTURE"
+
+const defaultHttpFixturePath = "testdata/http_fixture.json"
+
+// Replayed requests are not sent, any host and access token will do
+const (
+	replayHost        = "http://fusion.replay"
+	replayAccessToken = "replayed-access-token"
+)
+
+var httpFixtureMode = httpfixture.Mode(os.Getenv(varHttpFixtureMode))
+
+// Set by TestMain, depending on the mode
+var httpRecorder *httpfixture.Recorder
+var httpReplayer *httpfixture.Replayer
+
+func httpFixturePath() string {
+	if path := os.Getenv(varHttpFixturePath); path != "" {
+		return path
+	}
+	return defaultHttpFixturePath
+}
+
+// Runs the tests while recording or replaying, and saves the recorded traffic.
+// When replaying, also fails if the tests which ran did not send some of the requests they recorded.
+func runWithHttpFixture(m *testing.M) int {
+	path := httpFixturePath()
+
+	switch httpFixtureMode {
+	case httpfixture.Record:
+		httpRecorder = httpfixture.NewRecorder(http.DefaultTransport)
+		code := m.Run()
+		if err := httpRecorder.Save(path); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot save the HTTP fixture: %s\n", err)
+			return 1
+		}
+		return code
+	case httpfixture.Replay:
+		fixture, err := httpfixture.Load(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "there is no HTTP fixture at %s yet, record it against a real Fusion first "+
+				"with %s=%s\n", path, varHttpFixtureMode, httpfixture.Record)
+			return 1
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "cannot load the HTTP fixture: %s\n", err)
+			return 1
+		}
+		httpReplayer = httpfixture.NewReplayer(fixture)
+		code := m.Run()
+		if unused := httpReplayer.Unused(); code == 0 && len(unused) != 0 {
+			fmt.Fprintf(os.Stderr, "the provider did not send these recorded requests:\n  %s\n", strings.Join(unused, "\n  "))
+			return 1
+		}
+		return code
+	default:
+		fmt.Fprintf(os.Stderr, "%s must be %s or %s, not %s\n", varHttpFixtureMode, httpfixture.Record, httpfixture.Replay, httpFixtureMode)
+		return 1
+	}
+}
+
+// Returns the transport sending the requests of the tests to Fusion, or to the recorded traffic when replaying
+func testTransport() http.RoundTripper {
+	switch {
+	case httpRecorder != nil:
+		return httpRecorder
+	case httpReplayer != nil:
+		return httpReplayer
+	}
+	return http.DefaultTransport
+}
+
+// Configures the provider of the acceptance tests. When replaying, no credentials are needed.
+func testConfigureProvider(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	if httpReplayer == nil {
+		return configureProvider(ctx, d, testTransport())
+	}
+
+	client, err := newReplayHMClient(ctx)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return newConfiguredClient(ctx, client), nil
+}
+
+func newReplayHMClient(ctx context.Context) (*hmrest.APIClient, error) {
+	transport := utilities.NewRetryTransport(httpReplayer, utilities.DefaultMaxRetries, utilities.DefaultRetryMaxBackoff)
+	return NewHMClientWithAccessToken(ctx, replayHost, replayAccessToken, transport)
+}
+
+// Opts the acceptance test in to recording and replaying. It seeds the random names of the test, so that the
+// test sends the same requests when replayed as when recorded: call it before generating any name.
+// The requests sent until the test ends are recorded for, and replayed from, the traffic of the test:
+// run it with testAccHttpFixtureTest rather than in parallel with the other tests.
+func testAccHttpFixture(t *testing.T) {
+	switch {
+	case httpRecorder != nil:
+		seed := time.Now().UnixNano()
+		httpRecorder.SetSeed(t.Name(), seed)
+		rand.Seed(seed)
+		httpRecorder.SetTest(t.Name())
+		t.Cleanup(func() { httpRecorder.SetTest("") })
+	case httpReplayer != nil:
+		seed, ok := httpReplayer.Seed(t.Name())
+		if !ok {
+			t.Skipf("%s has no recorded traffic in %s", t.Name(), httpFixturePath())
+		}
+		rand.Seed(seed)
+		httpReplayer.SetTest(t.Name())
+		t.Cleanup(func() { httpReplayer.SetTest("") })
+	}
+}
+
+// Runs the acceptance test in parallel with the others, unless its traffic is recorded or replayed:
+// the tests then run one at a time, so that the requests each of them sends are told apart.
+func testAccHttpFixtureTest(t *testing.T, c resource.TestCase) {
+	if httpRecorder == nil && httpReplayer == nil {
+		resource.ParallelTest(t, c)
+		return
+	}
+	resource.Test(t, c)
+}
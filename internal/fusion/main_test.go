@@ -0,0 +1,63 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/fakefusion"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/httpfixture"
+)
+
+// Set to 1 to run the acceptance tests against an in-memory fake of the Fusion API, without credentials
+const varFakeApi = "FUSION_FAKE_API"
+
+// Runs the tests, or the sweepers with -sweep
+func TestMain(m *testing.M) {
+	if os.Getenv(varFakeApi) == "1" {
+		// The server is not closed, the process exits with its in-memory resources
+		server := fakefusion.NewServer()
+		if err := seedPreexistingInfrastructure(server); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot set up the fake Fusion API: %s\n", err)
+			os.Exit(1)
+		}
+		os.Setenv(hostVar, server.URL)
+		os.Setenv(accessTokenVar, "fake-access-token")
+	}
+
+	// The sweepers do not use the test transport, only the tests are recorded or replayed
+	flag.Parse()
+	if httpFixtureMode != httpfixture.Off && flag.Lookup("sweep").Value.String() == "" {
+		os.Exit(runWithHttpFixture(m))
+	}
+	resource.TestMain(m)
+}
+
+// The fake comes with the default Region and Availability Zone, others are added with an Array in them
+func seedPreexistingInfrastructure(server *fakefusion.Server) error {
+	region := "/regions/" + preexistingRegion
+	availabilityZone := region + "/availability-zones/" + preexistingAvailabilityZone
+
+	if preexistingRegion != fakefusion.DefaultRegion {
+		if err := server.Seed("/regions", map[string]interface{}{"name": preexistingRegion}); err != nil {
+			return err
+		}
+	}
+	if preexistingRegion != fakefusion.DefaultRegion || preexistingAvailabilityZone != fakefusion.DefaultAvailabilityZone {
+		if err := server.Seed(region+"/availability-zones", map[string]interface{}{"name": preexistingAvailabilityZone}); err != nil {
+			return err
+		}
+		return server.Seed(availabilityZone+"/arrays", map[string]interface{}{
+			"name": fakefusion.DefaultArray, "hardware_type": "flash-array-x", "host_name": fakefusion.DefaultArray,
+		})
+	}
+	return nil
+}
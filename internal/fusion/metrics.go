@@ -0,0 +1,71 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Fusion reports performance and space metrics for these kinds of objects.
+// The metrics data sources identify the object with the same arguments, the object itself always by its name.
+
+func schemaMetricsIdentity(nameDescription string, parents map[string]string) map[string]*schema.Schema {
+	result := map[string]*schema.Schema{
+		optionName: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  nameDescription,
+		},
+	}
+	for option, description := range parents {
+		result[option] = &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  description,
+		}
+	}
+	return result
+}
+
+func schemaVolumeMetricsIdentity() map[string]*schema.Schema {
+	return schemaMetricsIdentity("The name of the Volume.", map[string]string{
+		optionTenant:      "The name of the Tenant.",
+		optionTenantSpace: "The name of the Tenant Space.",
+	})
+}
+
+func schemaPlacementGroupMetricsIdentity() map[string]*schema.Schema {
+	return schemaMetricsIdentity("The name of the Placement Group.", map[string]string{
+		optionTenant:      "The name of the Tenant.",
+		optionTenantSpace: "The name of the Tenant Space.",
+	})
+}
+
+func schemaTenantSpaceMetricsIdentity() map[string]*schema.Schema {
+	return schemaMetricsIdentity("The name of the Tenant Space.", map[string]string{
+		optionTenant: "The name of the Tenant.",
+	})
+}
+
+func schemaTenantMetricsIdentity() map[string]*schema.Schema {
+	return schemaMetricsIdentity("The name of the Tenant.", nil)
+}
+
+func schemaAvailabilityZoneMetricsIdentity() map[string]*schema.Schema {
+	return schemaMetricsIdentity("The name of the Availability Zone.", map[string]string{
+		optionRegion: "The name of the Region.",
+	})
+}
+
+func schemaArrayMetricsIdentity() map[string]*schema.Schema {
+	return schemaMetricsIdentity("The name of the Array.", map[string]string{
+		optionRegion:           "The name of the Region.",
+		optionAvailabilityZone: "The name of the Availability Zone.",
+	})
+}
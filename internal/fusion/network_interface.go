@@ -161,13 +161,13 @@ func (p *networkInterfaceProvider) PrepareCreate(ctx context.Context, d *schema.
 	name := rdString(ctx, d, optionName)
 	tflog.Warn(ctx, "Network Interface cannot be really created as it is tied to its array and must already exist to modify it", "network_interface", name)
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		return patchNetworkInterface(ctx, client, d)
 	}
 	return fn, nil, nil
 }
 
-func (p *networkInterfaceProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (p *networkInterfaceProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	ni, _, err := client.NetworkInterfacesApi.GetNetworkInterfaceById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -176,7 +176,7 @@ func (p *networkInterfaceProvider) ReadResource(ctx context.Context, client *hmr
 	return p.loadNetworkInterface(ni, d)
 }
 
-func (p *networkInterfaceProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (p *networkInterfaceProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	// TODO: Right now there is no way to directly emit diagnostics without significant refactoring.
 	// Once `ResourceProvider` is refactored, emit warning about the network interface not really
 	// being destroyed as its lifecycle is directly tied to the array.
@@ -186,7 +186,7 @@ func (p *networkInterfaceProvider) PrepareDelete(ctx context.Context, client *hm
 	return DummyInvokeWriteAPI, nil
 }
 
-func (p *networkInterfaceProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+func (p *networkInterfaceProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
 	if err := utilities.CheckImmutableFieldsExcept(ctx, d, optionDisplayName, optionEnabled, optionNetworkInterfaceGroup, optionEth, optionFc); err != nil {
 		d.Partial(true)
 		return nil, nil, err
@@ -198,7 +198,7 @@ func (p *networkInterfaceProvider) PrepareUpdate(ctx context.Context, client *hm
 	return DummyInvokeWriteAPI, []ResourcePatch{}, nil
 }
 
-func (p *networkInterfaceProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *networkInterfaceProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{
 		resourceGroupNameRegion,
 		resourceGroupNameAvailabilityZone,
@@ -268,7 +268,7 @@ func (p *networkInterfaceProvider) loadNetworkInterface(ni hmrest.NetworkInterfa
 	return err
 }
 
-func patchNetworkInterface(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (op *hmrest.Operation, err error) {
+func patchNetworkInterface(ctx context.Context, client *Client, d *schema.ResourceData) (op *hmrest.Operation, err error) {
 	region := rdString(ctx, d, optionRegion)
 	availabilityZone := rdString(ctx, d, optionAvailabilityZone)
 	array := rdString(ctx, d, optionArray)
@@ -342,7 +342,7 @@ func patchNetworkInterface(ctx context.Context, client *hmrest.APIClient, d *sch
 		// await the update here and return fake op instead since BaseResourceProvider.PrepareCreate() expects Create() op which can be a bit different
 		utilities.TraceOperation(ctx, &op, "Patching Network Interface")
 
-		succeeded, err := utilities.WaitOnOperation(ctx, &op, client)
+		succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
 		if err != nil {
 			d.Partial(true)
 			return &op, err
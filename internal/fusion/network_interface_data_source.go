@@ -13,7 +13,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 type networkInterfaceDataSource struct{}
@@ -62,7 +61,7 @@ func dataSourceNetworkInterface() *schema.Resource {
 	return networkInterfaceDataSourceFunctions.Resource
 }
 
-func (ds *networkInterfaceDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *networkInterfaceDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	region := rdString(ctx, d, optionRegion)
 	availabilityZone := rdString(ctx, d, optionAvailabilityZone)
 	array := rdString(ctx, d, optionArray)
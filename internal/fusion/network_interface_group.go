@@ -133,14 +133,14 @@ func (p *networkInterfaceGroupProvider) PrepareCreate(ctx context.Context, d *sc
 		}
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.NetworkInterfaceGroupsApi.CreateNetworkInterfaceGroup(ctx, *body.(*hmrest.NetworkInterfaceGroupPost), region, availabilityZone, nil)
 		return &op, err
 	}
 	return fn, &body, nil
 }
 
-func (p *networkInterfaceGroupProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (p *networkInterfaceGroupProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	nig, _, err := client.NetworkInterfaceGroupsApi.GetNetworkInterfaceGroupById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -149,19 +149,19 @@ func (p *networkInterfaceGroupProvider) ReadResource(ctx context.Context, client
 	return p.loadNetworkInterfaceGroup(nig, d)
 }
 
-func (p *networkInterfaceGroupProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (p *networkInterfaceGroupProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	name := rdString(ctx, d, optionName)
 	availabilityZone := rdString(ctx, d, optionAvailabilityZone)
 	region := rdString(ctx, d, optionRegion)
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.NetworkInterfaceGroupsApi.DeleteNetworkInterfaceGroup(ctx, region, availabilityZone, name, nil)
 		return &op, err
 	}
 	return fn, nil
 }
 
-func (p *networkInterfaceGroupProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+func (p *networkInterfaceGroupProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
 	if d.HasChangeExcept(optionDisplayName) {
 		d.Partial(true)
 		return nil, nil, fmt.Errorf("attempting to update an immutable field")
@@ -179,14 +179,14 @@ func (p *networkInterfaceGroupProvider) PrepareUpdate(ctx context.Context, clien
 		},
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.NetworkInterfaceGroupsApi.UpdateNetworkInterfaceGroup(ctx, *body.(*hmrest.NetworkInterfaceGroupPatch), region, availabilityZone, name, nil)
 		return &op, err
 	}
 	return fn, patches, nil
 }
 
-func (p *networkInterfaceGroupProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *networkInterfaceGroupProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{
 		resourceGroupNameRegion,
 		resourceGroupNameAvailabilityZone,
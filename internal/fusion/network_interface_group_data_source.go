@@ -12,7 +12,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 // Implements DataSource
@@ -50,7 +49,7 @@ func dataSourceNetworkInterfaceGroup() *schema.Resource {
 	return networkInterfaceGroupDataSourceFunctions.Resource
 }
 
-func (ds *networkInterfaceGroupDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *networkInterfaceGroupDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	region := rdString(ctx, d, optionRegion)
 	availabilityZone := rdString(ctx, d, optionAvailabilityZone)
 
@@ -19,8 +19,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 const (
@@ -284,7 +282,7 @@ func testNetworkInterfaceGroupExists(rName string) resource.TestCheckFunc {
 		}
 		attrs := resource.Primary.Attributes
 
-		client, _, err := testAccProvider.Meta().(*hmrest.APIClient).NetworkInterfaceGroupsApi.GetNetworkInterfaceGroupById(context.Background(), attrs["id"], nil)
+		client, _, err := testAccProvider.Meta().(*Client).NetworkInterfaceGroupsApi.GetNetworkInterfaceGroupById(context.Background(), attrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client returned error while searching for %s by id: %s. Error: %s", attrs["name"], attrs["id"], err)
 		}
@@ -321,7 +319,7 @@ func testNetworkInterfaceGroupExists(rName string) resource.TestCheckFunc {
 }
 
 func testCheckNetworkInterfaceGroupDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_network_interface_group" {
@@ -203,7 +203,7 @@ func createNetworkInterfaceRevertFunc(ctx context.Context, t *testing.T, client
 				t.Fatalf("hmClient.NetworkInterfacesApi.UpdateNetworkInterface('%s', '%s', '%s', '%s'): %v", origIface.Region.Name, origIface.AvailabilityZone.Name, origIface.Array.Name, origIface.Name, err)
 			}
 
-			_, _ = utilities.WaitOnOperation(ctx, &op, client)
+			_, _ = utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
 		}
 	}
 }
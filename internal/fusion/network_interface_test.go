@@ -205,7 +205,7 @@ func testNetworkInterfaceExists(rName string) resource.TestCheckFunc {
 		}
 		attrs := tfNetworkInterface.Primary.Attributes
 
-		remote, _, err := testAccProvider.Meta().(*hmrest.APIClient).NetworkInterfacesApi.GetNetworkInterfaceById(context.Background(), attrs["id"], nil)
+		remote, _, err := testAccProvider.Meta().(*Client).NetworkInterfacesApi.GetNetworkInterfaceById(context.Background(), attrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client returned error while searching for %s with %s. Error: %s", attrs["name"], attrs["id"], err)
 		}
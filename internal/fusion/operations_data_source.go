@@ -0,0 +1,310 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+var operationStatuses = []string{
+	"Pending", "Running", "Aborting", "Succeeded", "Failed",
+}
+
+// Implements DataSource
+type operationsDataSource struct{}
+
+// This is our entry point for the Operations data source
+func dataSourceOperations() *schema.Resource {
+	ds := &operationsDataSource{}
+
+	dsSchema := map[string]*schema.Schema{
+		optionAction: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Only Operations of this action, e.g. `Create`, `Update` or `Delete`.",
+		},
+		optionResourceKind: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Only Operations performed on resources of this kind, e.g. `Volume`.",
+		},
+		optionResourceId: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Only Operations performed on the resource with this ID.",
+		},
+		optionStatus: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(operationStatuses, false),
+			Description:  "Only Operations with this status, one of `Pending`, `Running`, `Aborting`, `Succeeded` or `Failed`.",
+		},
+		optionRequestId: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Only Operations created by the request with this ID.",
+		},
+		optionRequestCollection: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The collection the Operations were created in, either `/`, `/tenants/<tenant>` or `/tenants/<tenant>/tenant-spaces/<tenant-space>`. Defaults to `/`.",
+		},
+		optionCreatedAfter: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+			Description:  "Only Operations created after this time, in RFC 3339 format, e.g. `2023-01-31T08:00:00Z`.",
+		},
+		optionItems: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: schemaOperation(),
+			},
+			Description: "List of matching Operations.",
+		},
+	}
+	withListOptions(dsSchema, "Operations")
+
+	operationsDataSourceFunctions := NewBaseDataSourceFunctions("Operation", ds, dsSchema)
+	operationsDataSourceFunctions.Resource.Description = "Provides the history of the Operations which changed resources in Fusion, " +
+		"e.g. to find out when a Volume or a Placement Group was changed outside of Terraform."
+
+	return operationsDataSourceFunctions.Resource
+}
+
+func schemaOperation() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		optionId: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The UUID of the Operation.",
+		},
+		optionRequestType: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Combination of the action and the resource kind, e.g. `CreateVolume`.",
+		},
+		optionRequestId: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the request which created the Operation.",
+		},
+		optionRequestCollection: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The collection the Operation was created in.",
+		},
+		optionStatus: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The latest status of the Operation.",
+		},
+		optionResourceKind: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The kind of the resource the Operation was performed on.",
+		},
+		optionResourceId: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the resource the Operation was performed on.",
+		},
+		optionResourceName: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the resource the Operation was performed on. Empty if the resource no longer exists.",
+		},
+		optionUpdateFields: {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: "The fields an Update Operation changed, with their new values.",
+		},
+		optionCreatedAt: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The time the Operation was created, in milliseconds since the Unix epoch.",
+		},
+		optionStartedAt: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The time the Operation was admitted, in milliseconds since the Unix epoch.",
+		},
+		optionEndedAt: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The time the Operation succeeded or failed, in milliseconds since the Unix epoch.",
+		},
+		optionError: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					optionMessage: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					optionPureCode: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					optionHttpCode: {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					optionDetails: {
+						Type:     schema.TypeMap,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+			Description: "Why the Operation failed.",
+		},
+	}
+}
+
+func (ds *operationsDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	var opts hmrest.OperationsApiListOperationsOpts
+
+	for option, value := range map[string]*optional.String{
+		optionAction:            &opts.Action,
+		optionResourceKind:      &opts.ResourceKind,
+		optionResourceId:        &opts.ResourceId,
+		optionStatus:            &opts.Status,
+		optionRequestId:         &opts.RequestId,
+		optionRequestCollection: &opts.RequestCollection,
+	} {
+		if v := rdString(ctx, d, option); v != "" {
+			*value = optional.NewString(v)
+		}
+	}
+
+	if createdAfter := rdString(ctx, d, optionCreatedAfter); createdAfter != "" {
+		createdAfterTime, err := time.Parse(time.RFC3339, createdAfter)
+		if err != nil {
+			return err
+		}
+		opts.CreatedAfter = optional.NewString(strconv.FormatInt(createdAfterTime.UnixMilli(), 10))
+	}
+
+	listOpts := rdListOptions(d)
+	opts.Filter = optionalString(listOpts.filter)
+	opts.Sort = optionalString(listOpts.sort)
+
+	var operations []hmrest.Operation
+	moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		opts.Limit = optional.NewInt32(limit)
+		opts.Offset = optional.NewInt32(offset)
+		resp, _, err := client.OperationsApi.ListOperations(ctx, &opts)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		operations = append(operations, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, "Listed operations", "count", len(operations), "more_items_remaining", moreItemsRemaining)
+
+	operationList := make([]map[string]interface{}, 0, len(operations))
+	for _, op := range operations {
+		operationList = append(operationList, flattenOperation(op))
+	}
+
+	err = getFirstError(
+		d.Set(optionItems, operationList),
+		d.Set(optionMoreItemsRemaining, moreItemsRemaining),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(utilities.GetIdForDataSource())
+
+	return nil
+}
+
+func flattenOperation(op hmrest.Operation) map[string]interface{} {
+	result := map[string]interface{}{
+		optionId:                op.Id,
+		optionRequestType:       op.RequestType,
+		optionRequestId:         op.RequestId,
+		optionRequestCollection: op.RequestCollection,
+		optionStatus:            op.Status,
+		optionUpdateFields:      op.UpdateFields,
+		optionCreatedAt:         op.CreatedAt,
+		optionStartedAt:         op.StartedAt,
+		optionEndedAt:           op.EndedAt,
+	}
+
+	// The requested resource has no ID yet when the Operation creates it, the result has it then
+	for _, resource := range []*hmrest.ResourceReference{resultResource(op), requestResource(op)} {
+		if resource == nil {
+			continue
+		}
+		for option, value := range map[string]string{
+			optionResourceKind: resource.Kind,
+			optionResourceId:   resource.Id,
+			optionResourceName: resource.Name,
+		} {
+			if result[option] == nil || result[option] == "" {
+				result[option] = value
+			}
+		}
+	}
+
+	opError := op.Error_
+	if opError == nil && op.State != nil {
+		opError = op.State.Error_
+	}
+	if opError != nil {
+		result[optionError] = []map[string]interface{}{{
+			optionMessage:  opError.Message,
+			optionPureCode: opError.PureCode,
+			optionHttpCode: opError.HttpCode,
+			optionDetails:  opError.Details,
+		}}
+	}
+
+	return result
+}
+
+func requestResource(op hmrest.Operation) *hmrest.ResourceReference {
+	if op.Request == nil {
+		return nil
+	}
+	return op.Request.Resource
+}
+
+func resultResource(op hmrest.Operation) *hmrest.ResourceReference {
+	if op.Result == nil {
+		return nil
+	}
+	return op.Result.Resource
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Serves GET /operations from a list of the given number of operations, honoring limit and offset
+func testOperationsClient(t *testing.T, count int) (*Client, *[]string) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		list := hmrest.OperationList{}
+		for i := offset; i < count && i < offset+limit; i++ {
+			list.Items = append(list.Items, hmrest.Operation{
+				Id:          fmt.Sprintf("op-%d", i),
+				RequestType: "UpdateVolume",
+				Status:      "Succeeded",
+				Request:     &hmrest.OperationRequest{Resource: &hmrest.ResourceReference{Kind: "Volume", Name: "vol1"}},
+				Result:      &hmrest.OperationResult{Resource: &hmrest.ResourceReference{Kind: "Volume", Id: "vol-id"}},
+			})
+		}
+		list.Count = int32(len(list.Items))
+		list.MoreItemsRemaining = offset+limit < count
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list)
+	}))
+	t.Cleanup(server.Close)
+
+	client := newClient(hmrest.NewAPIClient(&hmrest.Configuration{BasePath: server.URL, DefaultHeader: map[string]string{}, HTTPClient: server.Client()}))
+	return client, &queries
+}
+
+func TestOperationsDataSource_pagesThroughAllOperations(t *testing.T) {
+	client, queries := testOperationsClient(t, 2*listPageSize+5)
+	d := dataSourceOperations().TestResourceData()
+	d.Set(optionStatus, "Succeeded")
+
+	if err := (&operationsDataSource{}).ReadDataSource(context.Background(), client, d); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := d.Get(optionItems + ".#").(int); got != 2*listPageSize+5 {
+		t.Errorf("expected all operations, got %d", got)
+	}
+	if len(*queries) != 3 {
+		t.Errorf("expected 3 pages, got %d", len(*queries))
+	}
+	if d.Get(optionMoreItemsRemaining).(bool) {
+		t.Errorf("expected no more items remaining")
+	}
+	for _, query := range *queries {
+		if !strings.Contains(query, "status=Succeeded") {
+			t.Errorf("expected the status filter in every page, got %q", query)
+		}
+	}
+}
+
+func TestOperationsDataSource_limitAndOffset(t *testing.T) {
+	client, queries := testOperationsClient(t, 50)
+	d := dataSourceOperations().TestResourceData()
+	d.Set(optionLimit, 10)
+	d.Set(optionOffset, 5)
+
+	if err := (&operationsDataSource{}).ReadDataSource(context.Background(), client, d); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := d.Get(optionItems + ".#").(int); got != 10 {
+		t.Errorf("expected 10 operations, got %d", got)
+	}
+	if got := d.Get(optionItems + ".0." + optionId).(string); got != "op-5" {
+		t.Errorf("expected the first operation to be op-5, got %q", got)
+	}
+	if len(*queries) != 1 {
+		t.Errorf("expected a single page, got %d", len(*queries))
+	}
+	if !d.Get(optionMoreItemsRemaining).(bool) {
+		t.Errorf("expected more items remaining")
+	}
+}
+
+func TestFlattenOperation_mergesRequestAndResultResources(t *testing.T) {
+	op := hmrest.Operation{
+		Id:      "op-1",
+		Status:  "Failed",
+		Request: &hmrest.OperationRequest{Resource: &hmrest.ResourceReference{Kind: "Volume", Name: "vol1"}},
+		Result:  &hmrest.OperationResult{Resource: &hmrest.ResourceReference{Kind: "Volume", Id: "vol-id"}},
+		Error_:  &hmrest.ModelError{Message: "no space left", PureCode: "EXHAUSTED", HttpCode: 409},
+	}
+
+	result := flattenOperation(op)
+
+	if result[optionResourceId] != "vol-id" || result[optionResourceName] != "vol1" || result[optionResourceKind] != "Volume" {
+		t.Errorf("unexpected resource: %v %v %v", result[optionResourceKind], result[optionResourceId], result[optionResourceName])
+	}
+	opError := result[optionError].([]map[string]interface{})[0]
+	if opError[optionPureCode] != "EXHAUSTED" || opError[optionHttpCode] != int32(409) {
+		t.Errorf("unexpected error: %v", opError)
+	}
+}
+
+// Finds the operation which created a tenant
+func TestAccOperationsDataSource_basic(t *testing.T) {
+	utilities.CheckTestSkip(t)
+
+	tenant := acctest.RandomWithPrefix("ops-ds-test-tenant")
+
+	dataSource := fmt.Sprintf(`
+	data "fusion_operations" "tenant" {
+		resource_kind = "Tenant"
+		resource_id   = fusion_tenant.%[1]s.id
+		action        = "Create"
+		status        = "Succeeded"
+	}
+	`, tenant)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testCheckTenantDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testTenantConfig(tenant, tenant, tenant) + dataSource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fusion_operations.tenant", "items.#", "1"),
+					resource.TestCheckResourceAttr("data.fusion_operations.tenant", "items.0.request_type", "CreateTenant"),
+					resource.TestCheckResourceAttr("data.fusion_operations.tenant", "items.0.resource_name", tenant),
+					resource.TestCheckResourceAttrPair("data.fusion_operations.tenant", "items.0.resource_id", "fusion_tenant."+tenant, "id"),
+					resource.TestCheckResourceAttr("data.fusion_operations.tenant", "more_items_remaining", "false"),
+				),
+			},
+		},
+	})
+}
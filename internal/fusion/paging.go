@@ -0,0 +1,140 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The number of items requested at once while paging through a list
+const listPageSize = 100
+
+// Lists a single page of items and appends them to the caller's result.
+// Returns the number of items on the page, and the count and more_items_remaining of the list response.
+type listPageFunc func(limit, offset int32) (itemCount int, count int32, moreItemsRemaining bool, err error)
+
+// How a data source lists its items: the server-side filter and sort, and which part of the list to return
+type listOptions struct {
+	filter string
+	sort   string
+	limit  int // 0 returns all the items
+	offset int
+}
+
+// Arguments of the data sources listing items from an API supporting filtering, sorting and paging
+func schemaListOptions(items string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		optionFilter: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "Only the " + items + " matching this Fusion API filter expression.",
+		},
+		optionSort: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description: "The fields to sort the " + items + " by, separated by commas. " +
+				"Append `-` to a field to sort in descending order, e.g. `created_at-,name`.",
+		},
+		optionLimit: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "The maximum number of " + items + " to return. All the matching " + items + " are returned if not set.",
+		},
+		optionOffset: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The number of matching " + items + " to skip.",
+		},
+		optionMoreItemsRemaining: {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "True if `limit` cut the list short and more " + items + " match.",
+		},
+	}
+}
+
+// Adds the filtering, sorting and paging arguments to the schema of a data source
+func withListOptions(dsSchema map[string]*schema.Schema, items string) map[string]*schema.Schema {
+	for option, optionSchema := range schemaListOptions(items) {
+		dsSchema[option] = optionSchema
+	}
+	return dsSchema
+}
+
+// An argument matching the items with the given value of a field
+func schemaListMatch(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+		Description:  description,
+	}
+}
+
+func rdListOptions(d *schema.ResourceData) listOptions {
+	return listOptions{
+		filter: d.Get(optionFilter).(string),
+		sort:   d.Get(optionSort).(string),
+		limit:  d.Get(optionLimit).(int),
+		offset: d.Get(optionOffset).(int),
+	}
+}
+
+// Unset strings are not sent to the API at all
+func optionalString(value string) optional.String {
+	if value == "" {
+		return optional.EmptyString()
+	}
+	return optional.NewString(value)
+}
+
+// Unlike GetOk, tells an explicit false apart from an unset bool. GetOkExists is deprecated, but it is the only way to do it yet.
+func rdOptionalBool(d *schema.ResourceData, key string) optional.Bool {
+	if value, ok := d.GetOkExists(key); ok {
+		return optional.NewBool(value.(bool))
+	}
+	return optional.EmptyBool()
+}
+
+// Calls listPage until all the items, or limit items, starting at offset are listed.
+// A list call returns only a page of the items. The API sets more_items_remaining while there are more to get;
+// a count larger than the page is the count of all the matching items, so there are more to get too.
+// Returns true if limit cut the list short while more items remain.
+func (o listOptions) listAll(ctx context.Context, listPage listPageFunc) (bool, error) {
+	listed := 0
+	for {
+		pageSize := listPageSize
+		if o.limit > 0 && o.limit-listed < pageSize {
+			pageSize = o.limit - listed
+		}
+
+		itemCount, count, moreItemsRemaining, err := listPage(int32(pageSize), int32(o.offset+listed))
+		if err != nil {
+			return false, err
+		}
+		listed += itemCount
+
+		tflog.Trace(ctx, "listed page", "offset", o.offset+listed-itemCount, "item_count", itemCount, "count", count,
+			"more_items_remaining", moreItemsRemaining)
+
+		moreItemsRemaining = moreItemsRemaining || int(count) > itemCount && int(count) > o.offset+listed
+		if !moreItemsRemaining || itemCount == 0 {
+			return false, nil
+		}
+		if o.limit > 0 && listed >= o.limit {
+			return true, nil
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"testing"
+)
+
+// Pages through total items like the API does, counting either the page or all the matching items
+type testList struct {
+	total      int
+	countTotal bool
+	calls      int
+	listed     []int
+}
+
+func (l *testList) listPage(limit, offset int32) (int, int32, bool, error) {
+	l.calls++
+	itemCount := 0
+	for i := int(offset); i < l.total && itemCount < int(limit); i++ {
+		l.listed = append(l.listed, i)
+		itemCount++
+	}
+	moreItemsRemaining := int(offset)+itemCount < l.total
+	if l.countTotal {
+		return itemCount, int32(l.total), false, nil
+	}
+	return itemCount, int32(itemCount), moreItemsRemaining, nil
+}
+
+func TestListAll_allPages(t *testing.T) {
+	list := &testList{total: 2*listPageSize + 5}
+
+	moreItemsRemaining, err := listOptions{}.listAll(context.Background(), list.listPage)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(list.listed) != list.total || list.calls != 3 || moreItemsRemaining {
+		t.Errorf("expected %d items in 3 calls, got %d items in %d calls, more_items_remaining %t", list.total, len(list.listed), list.calls, moreItemsRemaining)
+	}
+}
+
+func TestListAll_countOfAllItems(t *testing.T) {
+	list := &testList{total: listPageSize + 5, countTotal: true}
+
+	if _, err := (listOptions{}).listAll(context.Background(), list.listPage); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(list.listed) != list.total || list.calls != 2 {
+		t.Errorf("expected %d items in 2 calls, got %d items in %d calls", list.total, len(list.listed), list.calls)
+	}
+}
+
+func TestListAll_limitAndOffset(t *testing.T) {
+	list := &testList{total: 2 * listPageSize}
+
+	moreItemsRemaining, err := listOptions{limit: listPageSize + 10, offset: 20}.listAll(context.Background(), list.listPage)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(list.listed) != listPageSize+10 || list.listed[0] != 20 || !moreItemsRemaining {
+		t.Errorf("expected %d items from 20 and more remaining, got %d items from %d, more_items_remaining %t",
+			listPageSize+10, len(list.listed), list.listed[0], moreItemsRemaining)
+	}
+}
+
+func TestListAll_limitCoversTheRest(t *testing.T) {
+	list := &testList{total: 30}
+
+	moreItemsRemaining, err := listOptions{limit: 30, offset: 10}.listAll(context.Background(), list.listPage)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(list.listed) != 20 || moreItemsRemaining {
+		t.Errorf("expected the last 20 items and none remaining, got %d items, more_items_remaining %t", len(list.listed), moreItemsRemaining)
+	}
+}
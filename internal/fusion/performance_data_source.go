@@ -0,0 +1,116 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+type getPerformanceFunc func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Performance, *http.Response, error)
+
+// Implements DataSource
+type performanceDataSource struct {
+	getPerformance getPerformanceFunc
+}
+
+func dataSourcePerformance(resourceKind, displayKind string, identity map[string]*schema.Schema, getPerformance getPerformanceFunc) *schema.Resource {
+	ds := &performanceDataSource{getPerformance: getPerformance}
+
+	dsSchema := identity
+	for option, description := range map[string]string{
+		optionReadsPerSec:    "Reads per second.",
+		optionReadLatencyUs:  "Read latency in microseconds.",
+		optionReadBandwidth:  "Read bandwidth in bytes per second.",
+		optionWritesPerSec:   "Writes per second.",
+		optionWriteLatencyUs: "Write latency in microseconds.",
+		optionWriteBandwidth: "Write bandwidth in bytes per second.",
+	} {
+		dsSchema[option] = &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: description,
+		}
+	}
+
+	performanceDataSourceFunctions := NewBaseDataSourceFunctions(resourceKind+"Performance", ds, dsSchema)
+	performanceDataSourceFunctions.Resource.Description = "Provides the current performance (IOPS, latency and bandwidth) of " + displayKind + "."
+
+	return performanceDataSourceFunctions.Resource
+}
+
+func dataSourceVolumePerformance() *schema.Resource {
+	return dataSourcePerformance(resourceKindVolume, "a Volume", schemaVolumeMetricsIdentity(),
+		func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Performance, *http.Response, error) {
+			return client.VolumesApi.GetVolumePerformance(ctx, rdString(ctx, d, optionTenant), rdString(ctx, d, optionTenantSpace),
+				rdString(ctx, d, optionName), nil)
+		})
+}
+
+func dataSourcePlacementGroupPerformance() *schema.Resource {
+	return dataSourcePerformance(resourceKindPlacementGroup, "a Placement Group", schemaPlacementGroupMetricsIdentity(),
+		func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Performance, *http.Response, error) {
+			return client.PlacementGroupsApi.GetPlacementGroupsPerformance(ctx, rdString(ctx, d, optionTenant), rdString(ctx, d, optionTenantSpace),
+				rdString(ctx, d, optionName), nil)
+		})
+}
+
+func dataSourceTenantSpacePerformance() *schema.Resource {
+	return dataSourcePerformance(resourceKindTenantSpace, "a Tenant Space", schemaTenantSpaceMetricsIdentity(),
+		func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Performance, *http.Response, error) {
+			return client.TenantSpacesApi.GetTenantSpacePerformance(ctx, rdString(ctx, d, optionTenant), rdString(ctx, d, optionName), nil)
+		})
+}
+
+func dataSourceTenantPerformance() *schema.Resource {
+	return dataSourcePerformance(resourceKindTenant, "a Tenant", schemaTenantMetricsIdentity(),
+		func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Performance, *http.Response, error) {
+			return client.TenantsApi.GetTenantPerformance(ctx, rdString(ctx, d, optionName), nil)
+		})
+}
+
+func dataSourceAvailabilityZonePerformance() *schema.Resource {
+	return dataSourcePerformance(resourceKindAvailabilityZone, "an Availability Zone", schemaAvailabilityZoneMetricsIdentity(),
+		func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Performance, *http.Response, error) {
+			return client.AvailabilityZonesApi.GetAvailabilityZonePerformance(ctx, rdString(ctx, d, optionRegion), rdString(ctx, d, optionName), nil)
+		})
+}
+
+func dataSourceArrayPerformance() *schema.Resource {
+	return dataSourcePerformance(resourceKindArray, "an Array", schemaArrayMetricsIdentity(),
+		func(ctx context.Context, client *Client, d *schema.ResourceData) (hmrest.Performance, *http.Response, error) {
+			return client.ArraysApi.GetArrayPerformance(ctx, rdString(ctx, d, optionRegion), rdString(ctx, d, optionAvailabilityZone),
+				rdString(ctx, d, optionName), nil)
+		})
+}
+
+func (ds *performanceDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	performance, _, err := ds.getPerformance(ctx, client, d)
+	if err != nil {
+		return err
+	}
+
+	err = getFirstError(
+		d.Set(optionReadsPerSec, performance.ReadsPerSec),
+		d.Set(optionReadLatencyUs, performance.ReadLatencyUs),
+		d.Set(optionReadBandwidth, performance.ReadBandwidth),
+		d.Set(optionWritesPerSec, performance.WritesPerSec),
+		d.Set(optionWriteLatencyUs, performance.WriteLatencyUs),
+		d.Set(optionWriteBandwidth, performance.WriteBandwidth),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(utilities.GetIdForDataSource())
+
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+var performanceAttributes = []string{
+	"reads_per_sec", "read_latency_us", "read_bandwidth", "writes_per_sec", "write_latency_us", "write_bandwidth",
+}
+
+func TestAccPerformanceDataSources_basic(t *testing.T) {
+	utilities.CheckTestSkip(t)
+
+	tenant := acctest.RandomWithPrefix("perf-ds-test-tenant")
+	tenantSpace := acctest.RandomWithPrefix("perf-ds-test-ts")
+	commonConfig := testTenantConfig(tenant, tenant, tenant) +
+		testTenantSpaceConfigWithRefs(tenantSpace, tenantSpace, tenantSpace, tenant)
+
+	arrays := getArraysInPreexistingRegionAndAZ(t)
+
+	dataSources := fmt.Sprintf(`
+	data "fusion_tenant_performance" "tenant" {
+		name = fusion_tenant.%[1]s.name
+	}
+
+	data "fusion_tenant_space_performance" "tenant_space" {
+		tenant = fusion_tenant.%[1]s.name
+		name   = fusion_tenant_space.%[2]s.name
+	}
+
+	data "fusion_availability_zone_performance" "az" {
+		region = "%[3]s"
+		name   = "%[4]s"
+	}
+
+	data "fusion_array_performance" "array" {
+		region            = "%[3]s"
+		availability_zone = "%[4]s"
+		name              = "%[5]s"
+	}
+	`, tenant, tenantSpace, preexistingRegion, preexistingAvailabilityZone, arrays[0].Name)
+
+	var checks []resource.TestCheckFunc
+	for _, ds := range []string{"fusion_tenant_performance.tenant", "fusion_tenant_space_performance.tenant_space",
+		"fusion_availability_zone_performance.az", "fusion_array_performance.array"} {
+		for _, attribute := range performanceAttributes {
+			checks = append(checks, resource.TestCheckResourceAttrSet("data."+ds, attribute))
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: commonConfig + dataSources,
+				Check:  resource.ComposeTestCheckFunc(checks...),
+			},
+		},
+	})
+}
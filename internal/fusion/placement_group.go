@@ -8,6 +8,7 @@ package fusion
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/antihax/optional"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -91,10 +92,67 @@ func resourcePlacementGroup() *schema.Resource {
 	placementGroupResourceFunctions := NewBaseResourceFunctions(resourceKindPlacementGroup, p)
 	placementGroupResourceFunctions.Resource.Description = "A Network Interface of an Array for use by Pure Fusion."
 	placementGroupResourceFunctions.Resource.Schema = schemaPlacementGroup()
+	placementGroupResourceFunctions.Resource.Schema[optionDeletionProtection] = schemaDeletionProtection("Placement Group")
+	placementGroupResourceFunctions.Resource.Schema[optionArray].ConflictsWith = []string{optionArraySelection}
+	for option, optionSchema := range schemaPlacementGroupArraySelection() {
+		placementGroupResourceFunctions.Resource.Schema[option] = optionSchema
+	}
 
 	return placementGroupResourceFunctions.Resource
 }
 
+// The array selection happens only when the Placement Group is created, the chosen array is kept afterwards
+func schemaPlacementGroupArraySelection() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		optionArraySelection: {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					optionPlacementEngine: {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice(placementEngines, false),
+						Description:  "The engine making the recommendation, either `heuristics` or `pure1meta`.",
+					},
+					optionTargetArrays: {
+						Type:     schema.TypeSet,
+						Optional: true,
+						Elem: &schema.Schema{
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						Description: "The names of the Arrays to choose from. All Arrays in the Availability Zone are considered if empty.",
+					},
+					optionExcludeArrays: {
+						Type:     schema.TypeSet,
+						Optional: true,
+						Elem: &schema.Schema{
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						Description: "The names of the Arrays which must not be chosen.",
+					},
+				},
+			},
+			Description: "Lets the Workload Planner choose the Array when the Placement Group is created. " +
+				"The Placement Group is placed to the top recommended Array. Changing the block later does not move the Placement Group. " +
+				"Requires Fusion API 1.2 or later.",
+		},
+		optionSelectedArray: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the Array chosen by the Workload Planner when the Placement Group was created.",
+		},
+		optionPlacementRecommendationId: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the Placement Recommendation report the Array was chosen from.",
+		},
+	}
+}
+
 func (p *placementGroupProvider) PrepareCreate(ctx context.Context, d *schema.ResourceData) (InvokeWriteAPI, ResourcePost, error) {
 	name := rdString(ctx, d, optionName)
 	tenantName := rdString(ctx, d, optionTenant)
@@ -109,13 +167,22 @@ func (p *placementGroupProvider) PrepareCreate(ctx context.Context, d *schema.Re
 		StorageService:   rdString(ctx, d, optionStorageService),
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
+		if selection, ok := d.GetOk(optionArraySelection); ok {
+			selected, err := p.selectArray(ctx, client, d, *body.(*hmrest.PlacementGroupPost), tenantName, tenantSpaceName,
+				selection.([]interface{})[0].(map[string]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			array = selected
+		}
+
 		op, _, err := client.PlacementGroupsApi.CreatePlacementGroup(ctx, *body.(*hmrest.PlacementGroupPost), tenantName, tenantSpaceName, nil)
 		if err != nil {
 			return &op, err
 		}
 
-		succeeded, err := utilities.WaitOnOperation(ctx, &op, client)
+		succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
 		if err != nil {
 			return &op, err
 		}
@@ -139,7 +206,56 @@ func (p *placementGroupProvider) PrepareCreate(ctx context.Context, d *schema.Re
 	return fn, &body, nil
 }
 
-func (p *placementGroupProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+// Asks the Workload Planner for the best Array for the Placement Group which is about to be created
+func (p *placementGroupProvider) selectArray(ctx context.Context, client *Client, d *schema.ResourceData,
+	body hmrest.PlacementGroupPost, tenantName, tenantSpaceName string, selection map[string]interface{},
+) (string, error) {
+	post := hmrest.PlacementRecommendationPost{
+		Name:        newPlacementRecommendationName(),
+		Tenant:      tenantName,
+		TenantSpace: tenantSpaceName,
+		SimulatedPlacement: &hmrest.SimulatedPlacementPost{
+			Region:           body.Region,
+			AvailabilityZone: body.AvailabilityZone,
+			StorageService:   body.StorageService,
+		},
+	}
+	if engine := selection[optionPlacementEngine].(string); engine != "" {
+		placementEngine := hmrest.PlacementEngine(engine)
+		post.PlacementEngine = &placementEngine
+	}
+	for _, array := range selection[optionTargetArrays].(*schema.Set).List() {
+		post.TargetArrays = append(post.TargetArrays, array.(string))
+	}
+	excluded := selection[optionExcludeArrays].(*schema.Set)
+
+	recommendation, err := createPlacementRecommendation(ctx, client, post)
+	if err != nil {
+		return "", err
+	}
+
+	for _, array := range recommendation.IncludedArrays {
+		if excluded.Contains(array.Name) {
+			continue
+		}
+
+		tflog.Info(ctx, "Array selected by the placement engine", "array", array.Name, "placement_recommendation", recommendation.Id)
+		err = getFirstError(
+			d.Set(optionSelectedArray, array.Name),
+			d.Set(optionPlacementRecommendationId, recommendation.Id),
+		)
+		return array.Name, err
+	}
+
+	reasons := make([]string, 0, len(recommendation.ExcludedArrays))
+	for _, array := range recommendation.ExcludedArrays {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", array.Name, array.Reason))
+	}
+	return "", fmt.Errorf("no array recommended for placement group %s (placement recommendation %s), excluded arrays: [%s]",
+		body.Name, recommendation.Name, strings.Join(reasons, ", "))
+}
+
+func (p *placementGroupProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	pg, _, err := client.PlacementGroupsApi.GetPlacementGroupById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -147,13 +263,13 @@ func (p *placementGroupProvider) ReadResource(ctx context.Context, client *hmres
 	return p.loadPlacementGroup(ctx, pg, client, d)
 }
 
-func (p *placementGroupProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (p *placementGroupProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	placementGroupName := rdString(ctx, d, optionName)
 	tenantName := rdString(ctx, d, optionTenant)
 	tenantSpaceName := rdString(ctx, d, optionTenantSpace)
 	destroySnaps := d.Get(optionDestroySnapshotsOnDelete).(bool)
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		if destroySnaps {
 			tflog.Debug(ctx, "Destroying relevant snapshots if they exist", optionTenant, tenantName, optionTenantSpace, tenantSpaceName)
 			snapshots, _, err := client.SnapshotsApi.ListSnapshots(ctx, tenantName, tenantSpaceName, &hmrest.SnapshotsApiListSnapshotsOpts{
@@ -178,8 +294,9 @@ func (p *placementGroupProvider) PrepareDelete(ctx context.Context, client *hmre
 	return fn, nil
 }
 
-func (p *placementGroupProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
-	if err := utilities.CheckImmutableFieldsExcept(ctx, d, optionDisplayName, optionArray, optionDestroySnapshotsOnDelete); err != nil {
+func (p *placementGroupProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+	if err := utilities.CheckImmutableFieldsExcept(ctx, d, optionDisplayName, optionArray, optionDestroySnapshotsOnDelete, optionArraySelection,
+		optionDeletionProtection); err != nil {
 		return nil, nil, err
 	}
 
@@ -205,7 +322,7 @@ func (p *placementGroupProvider) PrepareUpdate(ctx context.Context, client *hmre
 		})
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.PlacementGroupsApi.UpdatePlacementGroup(ctx, *body.(*hmrest.PlacementGroupPatch), tenantName, tenantSpaceName, name, nil)
 		return &op, err
 	}
@@ -213,7 +330,7 @@ func (p *placementGroupProvider) PrepareUpdate(ctx context.Context, client *hmre
 	return fn, patches, nil
 }
 
-func (p *placementGroupProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *placementGroupProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{
 		resourceGroupNameTenant,
 		resourceGroupNameTenantSpace,
@@ -241,7 +358,14 @@ func (p *placementGroupProvider) ImportResource(ctx context.Context, client *hmr
 	return []*schema.ResourceData{d}, nil
 }
 
-func (p *placementGroupProvider) loadPlacementGroup(ctx context.Context, pg hmrest.PlacementGroup, client *hmrest.APIClient, d *schema.ResourceData) error {
+// The Workload Planner choosing the array came with Fusion API 1.2
+func (p *placementGroupProvider) MinimumApiVersions() map[string]string {
+	return map[string]string{
+		optionArraySelection: "1.2",
+	}
+}
+
+func (p *placementGroupProvider) loadPlacementGroup(ctx context.Context, pg hmrest.PlacementGroup, client *Client, d *schema.ResourceData) error {
 
 	az, _, err := client.AvailabilityZonesApi.GetAvailabilityZoneById(ctx, pg.AvailabilityZone.Id, nil)
 	if err != nil {
@@ -44,6 +44,8 @@ func dataSourcePlacementGroup() *schema.Resource {
 			ValidateDiagFunc: IsValidIQN,
 			Description:      "The iSCSI qualified name (IQN) associated with the Placement Group.",
 		},
+		optionName:        schemaListMatch("Only the Placement Group with this name."),
+		optionDisplayName: schemaListMatch("Only the Placement Groups with this display name."),
 		optionItems: {
 			Type:     schema.TypeList,
 			Computed: true,
@@ -53,14 +55,14 @@ func dataSourcePlacementGroup() *schema.Resource {
 			Description: "List of matching Placement Groups.",
 		},
 	}
+	withListOptions(dsSchema, "Placement Groups")
 
 	placementGroupDataSourceFunctions := NewBaseDataSourceFunctions(resourceKindPlacementGroup, ds, dsSchema)
 
 	return placementGroupDataSourceFunctions.Resource
 }
 
-func (ds *placementGroupDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
-	var opts hmrest.PlacementGroupsApiListPlacementGroupsOpts
+func (ds *placementGroupDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	var requiredAZResourceGroupNames = []string{
 		resourceGroupNameRegion,
 		resourceGroupNameAvailabilityZone,
@@ -68,21 +70,34 @@ func (ds *placementGroupDataSource) ReadDataSource(ctx context.Context, client *
 
 	tenant := rdString(ctx, d, optionTenant)
 	tenantSpace := rdString(ctx, d, optionTenantSpace)
-
-	if iqn, ok := d.GetOk(optionIqn); ok {
-		opts = hmrest.PlacementGroupsApiListPlacementGroupsOpts{
-			Iqn: optional.NewString(iqn.(string)),
-		}
+	listOpts := rdListOptions(d)
+
+	opts := hmrest.PlacementGroupsApiListPlacementGroupsOpts{
+		Filter:      optionalString(listOpts.filter),
+		Sort:        optionalString(listOpts.sort),
+		Name:        optionalString(rdString(ctx, d, optionName)),
+		DisplayName: optionalString(rdString(ctx, d, optionDisplayName)),
+		Iqn:         optionalString(rdString(ctx, d, optionIqn)),
 	}
 
-	resp, _, err := client.PlacementGroupsApi.ListPlacementGroups(ctx, tenant, tenantSpace, &opts)
+	var placementGroups []hmrest.PlacementGroup
+	moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		opts.Limit = optional.NewInt32(limit)
+		opts.Offset = optional.NewInt32(offset)
+		resp, _, err := client.PlacementGroupsApi.ListPlacementGroups(ctx, tenant, tenantSpace, &opts)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		placementGroups = append(placementGroups, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	pgList := make([]map[string]interface{}, 0, resp.Count)
+	pgList := make([]map[string]interface{}, 0, len(placementGroups))
 
-	for _, pg := range resp.Items {
+	for _, pg := range placementGroups {
 		parsedSelfLink, err := utilities.ParseSelfLink(pg.AvailabilityZone.SelfLink, requiredAZResourceGroupNames)
 		if err != nil {
 			err := errors.New("invalid AZ self link, expected format: '/regions/<region>/availability-zones/<availability-zone>'")
@@ -102,7 +117,11 @@ func (ds *placementGroupDataSource) ReadDataSource(ctx context.Context, client *
 		})
 	}
 
-	if err := d.Set(optionItems, pgList); err != nil {
+	err = getFirstError(
+		d.Set(optionItems, pgList),
+		d.Set(optionMoreItemsRemaining, moreItemsRemaining),
+	)
+	if err != nil {
 		return err
 	}
 
@@ -0,0 +1,169 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Implements DataSource
+type placementGroupSessionsDataSource struct{}
+
+// This is our entry point for the Placement Group Sessions data source
+func dataSourcePlacementGroupSessions() *schema.Resource {
+	ds := &placementGroupSessionsDataSource{}
+
+	dsSchema := map[string]*schema.Schema{
+		optionTenant: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Tenant.",
+		},
+		optionTenantSpace: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Tenant Space.",
+		},
+		optionPlacementGroup: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Placement Group.",
+		},
+		optionItems: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: schemaPlacementGroupSession(),
+			},
+			Description: "List of the sessions hosts have opened to the Placement Group.",
+		},
+		optionInitiatorIqns: {
+			Type:     schema.TypeSet,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: "The iSCSI Qualified Names of all the Initiators with a session to the Placement Group.",
+		},
+	}
+
+	placementGroupSessionsDataSourceFunctions := NewBaseDataSourceFunctions("PlacementGroupSessions", ds, dsSchema)
+	placementGroupSessionsDataSourceFunctions.Resource.Description = "Provides the sessions hosts currently have open to a Placement Group."
+
+	return placementGroupSessionsDataSourceFunctions.Resource
+}
+
+func schemaPlacementGroupSession() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		optionProtocol: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Protocol name for the session.",
+		},
+		optionRegion: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the Region of the session.",
+		},
+		optionAvailabilityZone: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the Availability Zone of the session.",
+		},
+		optionInitiatorIqn: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The iSCSI Qualified Name of the Initiator.",
+		},
+		optionTargetIqn: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The iSCSI Qualified Name of the Target.",
+		},
+		optionInitiatorPortal: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "TCP/IP network address and tcp port number of the iSCSI Initiator.",
+		},
+		optionTargetPortal: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "TCP/IP network address and tcp port number of the iSCSI Target.",
+		},
+		optionTargetDiscoveryAddress: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The iSCSI Discovery Login IP for this session.",
+		},
+	}
+}
+
+func (ds *placementGroupSessionsDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	tenant := rdString(ctx, d, optionTenant)
+	tenantSpace := rdString(ctx, d, optionTenantSpace)
+	placementGroup := rdString(ctx, d, optionPlacementGroup)
+
+	resp, _, err := client.PlacementGroupsApi.GetPlacementGroupSessions(ctx, tenant, tenantSpace, placementGroup, nil)
+	if err != nil {
+		return err
+	}
+
+	sessionList := make([]map[string]interface{}, 0, len(resp.Items))
+	initiatorIqns := map[string]bool{}
+
+	for _, session := range resp.Items {
+		flat := map[string]interface{}{
+			optionProtocol: session.Protocol,
+		}
+
+		if az := session.AvailabilityZone; az != nil {
+			flat[optionAvailabilityZone] = az.Name
+			if az.Region != nil {
+				flat[optionRegion] = az.Region.Name
+			}
+		}
+
+		if iscsi := session.Iscsi; iscsi != nil {
+			flat[optionInitiatorIqn] = iscsi.InitiatorIqn
+			flat[optionTargetIqn] = iscsi.TargetIqn
+			flat[optionInitiatorPortal] = iscsi.InitiatorPortal
+			flat[optionTargetPortal] = iscsi.TargetPortal
+			flat[optionTargetDiscoveryAddress] = iscsi.TargetDiscoveryAddress
+			if iscsi.InitiatorIqn != "" {
+				initiatorIqns[iscsi.InitiatorIqn] = true
+			}
+		}
+
+		sessionList = append(sessionList, flat)
+	}
+
+	iqns := make([]string, 0, len(initiatorIqns))
+	for iqn := range initiatorIqns {
+		iqns = append(iqns, iqn)
+	}
+	sort.Strings(iqns)
+
+	err = getFirstError(
+		d.Set(optionItems, sessionList),
+		d.Set(optionInitiatorIqns, iqns),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(utilities.GetIdForDataSource())
+
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// No host can be connected to a freshly created placement group
+func TestAccPlacementGroupSessionsDataSource_basic(t *testing.T) {
+	utilities.CheckTestSkip(t)
+
+	arrays := getArraysInPreexistingRegionAndAZ(t)
+	if len(arrays) == 0 {
+		t.Error("did not find any arrays to test on !")
+	}
+
+	cfg, commonConfig := generatePlacementGroupTestConfigAndCommonTFConfig([]string{arrays[0].HardwareType.Name})
+
+	dataSource := fmt.Sprintf(`
+	data "fusion_placement_group_sessions" "sessions" {
+		tenant          = "%[1]s"
+		tenant_space    = "%[2]s"
+		placement_group = fusion_placement_group.%[3]s.name
+	}
+	`, cfg.Tenant, cfg.TenantSpace, cfg.Name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testCheckPlacementGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: commonConfig + testPlacementGroupConfigWithRefsNoArray(cfg.Name, cfg.Name, cfg.DisplayName, cfg.Tenant, cfg.TenantSpace, cfg.Region, cfg.AZ, cfg.StorageService, false) + dataSource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fusion_placement_group_sessions.sessions", "items.#", "0"),
+					resource.TestCheckResourceAttr("data.fusion_placement_group_sessions.sessions", "initiator_iqns.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -101,6 +101,41 @@ func TestAccPlacementGroup_basic(t *testing.T) {
 	})
 }
 
+// Lets the placement engine choose the array
+func TestAccPlacementGroup_arraySelection(t *testing.T) {
+	utilities.CheckTestSkip(t)
+
+	arrays := getArraysInPreexistingRegionAndAZ(t)
+	if len(arrays) == 0 {
+		t.Error("did not find any arrays to test on !")
+	}
+
+	cfg, commonConfig := generatePlacementGroupTestConfigAndCommonTFConfig([]string{arrays[0].HardwareType.Name})
+	pgConfig := testPlacementGroupConfigWithArraySelection(cfg.Name, cfg.Name, cfg.DisplayName, cfg.Tenant, cfg.TenantSpace, cfg.Region, cfg.AZ, cfg.StorageService, arrays[0].Name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testCheckPlacementGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: commonConfig + pgConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(cfg.RName, "array", arrays[0].Name),
+					resource.TestCheckResourceAttr(cfg.RName, "selected_array", arrays[0].Name),
+					resource.TestCheckResourceAttrSet(cfg.RName, "placement_recommendation_id"),
+					testPlacementGroupExists(t, cfg.RName),
+				),
+			},
+			// The choice is stable
+			{
+				Config:   commonConfig + pgConfig,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccPlacementGroup_update(t *testing.T) {
 	utilities.CheckTestSkip(t)
 
@@ -310,7 +345,7 @@ func TestAccPlacementGroup_import(t *testing.T) {
 				ImportStateVerify: true,
 				// skipping destroy_snapshots_on_delete field, this field is used as additional parameter for deletion
 				// destroy_snapshots_on_delete cannot be imported from harbormaster
-				ImportStateVerifyIgnore: []string{optionDestroySnapshotsOnDelete},
+				ImportStateVerifyIgnore: []string{optionDestroySnapshotsOnDelete, optionDeletionProtection},
 			},
 			{
 				ImportState:   true,
@@ -339,12 +374,12 @@ func testPlacementGroupExists(t *testing.T, rName string) resource.TestCheckFunc
 		}
 		tfAttrs := tfPG.Primary.Attributes
 
-		clientPG, _, err := testAccProvider.Meta().(*hmrest.APIClient).PlacementGroupsApi.GetPlacementGroupById(context.Background(), tfAttrs["id"], nil)
+		clientPG, _, err := testAccProvider.Meta().(*Client).PlacementGroupsApi.GetPlacementGroupById(context.Background(), tfAttrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client retutrned error while searching for %s by id: %s. Error: %s", tfAttrs["name"], tfAttrs["id"], err)
 		}
 
-		clientAZ, _, err := testAccProvider.Meta().(*hmrest.APIClient).AvailabilityZonesApi.GetAvailabilityZoneById(context.Background(), clientPG.AvailabilityZone.Id, nil)
+		clientAZ, _, err := testAccProvider.Meta().(*Client).AvailabilityZonesApi.GetAvailabilityZoneById(context.Background(), clientPG.AvailabilityZone.Id, nil)
 		if err != nil {
 			return fmt.Errorf("go client retutrned error while searching for AZ by id: %s. Error: %s", clientPG.AvailabilityZone.Id, err)
 		}
@@ -364,7 +399,7 @@ func testPlacementGroupExists(t *testing.T, rName string) resource.TestCheckFunc
 }
 
 func testCheckPlacementGroupDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_placement_group" {
@@ -414,6 +449,24 @@ func testPlacementGroupConfigWithRefs(rName, name, displayName, tenant, tenantSp
 	`, rName, name, displayName, tenant, tenantSpace, region, availabilityZone, storageService, array, destroySnap)
 }
 
+func testPlacementGroupConfigWithArraySelection(rName, name, displayName, tenant, tenantSpace, region, availabilityZone, storageService, targetArray string) string {
+	return fmt.Sprintf(`
+	resource "fusion_placement_group" "%[1]s" {
+		name                        = "%[2]s"
+		display_name                = "%[3]s"
+		tenant                      = fusion_tenant.%[4]s.name
+		tenant_space                = fusion_tenant_space.%[5]s.name
+		region                      = "%[6]s"
+		availability_zone           = "%[7]s"
+		storage_service             = fusion_storage_service.%[8]s.name
+		array_selection {
+			placement_engine = "heuristics"
+			target_arrays    = ["%[9]s"]
+		}
+	}
+	`, rName, name, displayName, tenant, tenantSpace, region, availabilityZone, storageService, targetArray)
+}
+
 func testPlacementGroupConfigWithRefsNoArray(rName, name, displayName, tenant, tenantSpace, region, availabilityZone, storageService string, destroySnap bool) string {
 	return fmt.Sprintf(`
 	resource "fusion_placement_group" "%[1]s" {
@@ -0,0 +1,367 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+var placementEngines = []string{
+	string(hmrest.HEURISTICS_PlacementEngine),
+	string(hmrest.PURE1META_PlacementEngine),
+}
+
+// Implements DataSource
+type placementRecommendationDataSource struct{}
+
+// This is our entry point for the Placement Recommendation data source
+func dataSourcePlacementRecommendation() *schema.Resource {
+	ds := &placementRecommendationDataSource{}
+
+	dsSchema := map[string]*schema.Schema{
+		optionTenant: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Tenant the Placement Group belongs, or would belong, to.",
+		},
+		optionTenantSpace: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Tenant Space the Placement Group belongs, or would belong, to.",
+		},
+		optionPlacementGroup: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			ExactlyOneOf: []string{optionPlacementGroup, optionSimulatedPlacement},
+			Description:  "The name of an existing Placement Group to recommend an array for.",
+		},
+		optionSimulatedPlacement: {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					optionRegion: {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						Description:  "The name of the Region the Placement Group would be created in.",
+					},
+					optionAvailabilityZone: {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						Description:  "The name of the Availability Zone the Placement Group would be created in.",
+					},
+					optionStorageService: {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						Description:  "The name of the Storage Service the Placement Group would be assigned.",
+					},
+				},
+			},
+			Description: "A Placement Group which does not exist yet, to recommend an array for.",
+		},
+		optionPlacementEngine: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(placementEngines, false),
+			Description:  "The engine making the recommendation, either `heuristics` or `pure1meta`.",
+		},
+		optionTargetArrays: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			Description: "The names of the Arrays to consider. All Arrays in the Availability Zone are considered if empty.",
+		},
+		optionName: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the Placement Recommendation report.",
+		},
+		optionIncludedArrays: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: schemaPlacementRecommendationIncludedArray(),
+			},
+			Description: "The Arrays the Placement Group can be placed on, the most recommended first.",
+		},
+		optionExcludedArrays: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					optionName: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					optionReason: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+			Description: "The Arrays the Placement Group cannot be placed on, with the reason why.",
+		},
+		optionTimeRemaining: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of milliseconds left before the Placement Recommendation report is deleted.",
+		},
+	}
+
+	placementRecommendationDataSourceFunctions := NewBaseDataSourceFunctions(resourceKindPlacementRecommendation, ds, dsSchema)
+	placementRecommendationDataSourceFunctions.Resource.Description = "Asks the Workload Planner which Arrays a new or an existing " +
+		"Placement Group fits on, together with capacity and load forecasts for each of them."
+
+	return placementRecommendationDataSourceFunctions.Resource
+}
+
+func schemaPure1MetaValues() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				optionTimestampMs: {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				optionValue: {
+					Type:     schema.TypeFloat,
+					Computed: true,
+				},
+				optionLower: {
+					Type:     schema.TypeFloat,
+					Computed: true,
+				},
+				optionUpper: {
+					Type:     schema.TypeFloat,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func schemaPlacementRecommendationIncludedArray() map[string]*schema.Schema {
+	objectives := map[string]*schema.Schema{}
+	for _, option := range []string{optionAvgPerfUsage, optionAvgCapUsage, optionVarPerfUsage, optionVarCapUsage, optionMaxPerfUsage, optionMaxCapUsage} {
+		objectives[option] = &schema.Schema{
+			Type:     schema.TypeFloat,
+			Computed: true,
+		}
+	}
+
+	return map[string]*schema.Schema{
+		optionName: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		optionDaysToReach90PercentCapacity: {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+		optionDaysToReach100PercentCapacity: {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+		optionCapacityValues: schemaPure1MetaValues(),
+		optionLoadAverage:    schemaPure1MetaValues(),
+		optionLoadBlendedMax: schemaPure1MetaValues(),
+		optionObjectives: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: objectives,
+			},
+		},
+		optionWarnings: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					optionMessage: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					optionWarningCode: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+		optionError: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (ds *placementRecommendationDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	post := hmrest.PlacementRecommendationPost{
+		Name:           newPlacementRecommendationName(),
+		Tenant:         rdString(ctx, d, optionTenant),
+		TenantSpace:    rdString(ctx, d, optionTenantSpace),
+		PlacementGroup: rdString(ctx, d, optionPlacementGroup),
+		TargetArrays:   rdStringSet(ctx, d, optionTargetArrays),
+	}
+
+	if engine := rdString(ctx, d, optionPlacementEngine); engine != "" {
+		placementEngine := hmrest.PlacementEngine(engine)
+		post.PlacementEngine = &placementEngine
+	}
+
+	if simulated, ok := d.GetOk(optionSimulatedPlacement); ok {
+		simulatedPlacement := simulated.([]interface{})[0].(map[string]interface{})
+		post.SimulatedPlacement = &hmrest.SimulatedPlacementPost{
+			Region:           simulatedPlacement[optionRegion].(string),
+			AvailabilityZone: simulatedPlacement[optionAvailabilityZone].(string),
+			StorageService:   simulatedPlacement[optionStorageService].(string),
+		}
+	}
+
+	recommendation, err := createPlacementRecommendation(ctx, client, post)
+	if err != nil {
+		return err
+	}
+
+	includedArrays := make([]map[string]interface{}, 0, len(recommendation.IncludedArrays))
+	for _, array := range recommendation.IncludedArrays {
+		includedArrays = append(includedArrays, flattenPlacementRecommendationIncludedArray(array))
+	}
+
+	excludedArrays := make([]map[string]interface{}, 0, len(recommendation.ExcludedArrays))
+	for _, array := range recommendation.ExcludedArrays {
+		excludedArrays = append(excludedArrays, map[string]interface{}{
+			optionName:   array.Name,
+			optionReason: array.Reason,
+		})
+	}
+
+	err = getFirstError(
+		d.Set(optionName, recommendation.Name),
+		d.Set(optionIncludedArrays, includedArrays),
+		d.Set(optionExcludedArrays, excludedArrays),
+		d.Set(optionTimeRemaining, recommendation.TimeRemaining),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(utilities.GetIdForDataSource())
+
+	return nil
+}
+
+// Placement Recommendation reports are short-lived, so the name only needs to be unique for a while
+func newPlacementRecommendationName() string {
+	return "terraform-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// Requests a Placement Recommendation report and returns it once the Workload Planner has finished it
+func createPlacementRecommendation(ctx context.Context, client *Client, post hmrest.PlacementRecommendationPost) (*hmrest.PlacementRecommendation, error) {
+	tflog.Debug(ctx, "Requesting placement recommendation", "post", post)
+
+	op, _, err := client.WorkloadPlannerApi.CreatePlacementRecommendation(ctx, post, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
+	if err != nil {
+		return nil, err
+	}
+	if !succeeded {
+		return nil, utilities.NewRestErrorFromOperation(&op)
+	}
+
+	recommendation, _, err := client.WorkloadPlannerApi.GetPlacementRecommendation(ctx, post.Name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get placement recommendation %s: %w", post.Name, err)
+	}
+
+	return &recommendation, nil
+}
+
+func flattenPure1MetaValues(values []hmrest.Pure1MetaValue) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(values))
+	for _, value := range values {
+		flat := map[string]interface{}{
+			optionTimestampMs: value.TimestampMs,
+			optionValue:       value.Value,
+		}
+		if value.ConfidenceInterval != nil {
+			flat[optionLower] = value.ConfidenceInterval.Lower
+			flat[optionUpper] = value.ConfidenceInterval.Upper
+		}
+		result = append(result, flat)
+	}
+	return result
+}
+
+func flattenPlacementRecommendationIncludedArray(array hmrest.PlacementRecommendationIncludedArray) map[string]interface{} {
+	result := map[string]interface{}{
+		optionName: array.Name,
+	}
+
+	// Only the pure1meta engine provides the forecasts
+	meta := array.Pure1meta
+	if meta == nil {
+		return result
+	}
+
+	result[optionDaysToReach90PercentCapacity] = meta.DaysToReach90PercentCapacity
+	result[optionDaysToReach100PercentCapacity] = meta.DaysToReach100PercentCapacity
+	result[optionCapacityValues] = flattenPure1MetaValues(meta.CapacityValues)
+	result[optionError] = meta.Error_
+
+	if meta.LoadValues != nil {
+		result[optionLoadAverage] = flattenPure1MetaValues(meta.LoadValues.Avg)
+		result[optionLoadBlendedMax] = flattenPure1MetaValues(meta.LoadValues.BlendedMax)
+	}
+
+	if meta.Objectives != nil {
+		result[optionObjectives] = []map[string]interface{}{{
+			optionAvgPerfUsage: meta.Objectives.AvgPerfUsage,
+			optionAvgCapUsage:  meta.Objectives.AvgCapUsage,
+			optionVarPerfUsage: meta.Objectives.VarPerfUsage,
+			optionVarCapUsage:  meta.Objectives.VarCapUsage,
+			optionMaxPerfUsage: meta.Objectives.MaxPerfUsage,
+			optionMaxCapUsage:  meta.Objectives.MaxCapUsage,
+		}}
+	}
+
+	warnings := make([]map[string]interface{}, 0, len(meta.Warnings))
+	for _, warning := range meta.Warnings {
+		warnings = append(warnings, map[string]interface{}{
+			optionMessage:     warning.Message,
+			optionWarningCode: warning.WarningCode,
+		})
+	}
+	result[optionWarnings] = warnings
+
+	return result
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Recommends one of the target arrays for a Placement Group which does not exist yet
+func TestAccPlacementRecommendationDataSource_simulatedPlacement(t *testing.T) {
+	utilities.CheckTestSkip(t)
+
+	dsNameConfig := acctest.RandomWithPrefix("placement_rec_ds_test")
+	tenant := acctest.RandomWithPrefix("placement-rec-test-tenant")
+	tenantSpace := acctest.RandomWithPrefix("placement-rec-test-ts")
+	storageService := acctest.RandomWithPrefix("placement-rec-test-ss")
+
+	arrays := getArraysInPreexistingRegionAndAZ(t)
+
+	commonConfig := testTenantConfig(tenant, tenant, tenant) +
+		testTenantSpaceConfigWithRefs(tenantSpace, tenantSpace, tenantSpace, tenant) +
+		testStorageServiceConfig(storageService, storageService, storageService, []string{arrays[0].HardwareType.Name})
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactory,
+		Steps: []resource.TestStep{
+			{
+				Config: commonConfig + testPlacementRecommendationDataSourceConfig(dsNameConfig, tenant, tenantSpace,
+					preexistingRegion, preexistingAvailabilityZone, storageService, arrays[0].Name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.fusion_placement_recommendation."+dsNameConfig, "name"),
+					resource.TestCheckResourceAttr("data.fusion_placement_recommendation."+dsNameConfig, "included_arrays.#", "1"),
+					resource.TestCheckResourceAttr("data.fusion_placement_recommendation."+dsNameConfig, "included_arrays.0.name", arrays[0].Name),
+				),
+			},
+		},
+	})
+}
+
+func testPlacementRecommendationDataSourceConfig(dsName, tenant, tenantSpace, region, availabilityZone, storageService, array string) string {
+	return fmt.Sprintf(`data "fusion_placement_recommendation" "%[1]s" {
+		tenant           = fusion_tenant_space.%[3]s.tenant
+		tenant_space     = fusion_tenant_space.%[3]s.name
+		placement_engine = "heuristics"
+		target_arrays    = ["%[7]s"]
+		simulated_placement {
+			region            = "%[4]s"
+			availability_zone = "%[5]s"
+			storage_service   = fusion_storage_service.%[6]s.name
+		}
+	}
+	`, dsName, tenant, tenantSpace, region, availabilityZone, storageService, array)
+}
@@ -94,7 +94,7 @@ func (p *protectionPolicyProvider) PrepareCreate(ctx context.Context, d *schema.
 		},
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.ProtectionPoliciesApi.CreateProtectionPolicy(ctx, *body.(*hmrest.ProtectionPolicyPost), nil)
 		return &op, err
 	}
@@ -102,7 +102,7 @@ func (p *protectionPolicyProvider) PrepareCreate(ctx context.Context, d *schema.
 	return fn, &body, nil
 }
 
-func (p *protectionPolicyProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (p *protectionPolicyProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	pp, _, err := client.ProtectionPoliciesApi.GetProtectionPolicyById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -111,11 +111,11 @@ func (p *protectionPolicyProvider) ReadResource(ctx context.Context, client *hmr
 	return p.loadProtectionPolicy(pp, d)
 }
 
-func (p *protectionPolicyProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (p *protectionPolicyProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	name := rdString(ctx, d, optionName)
 	destroySnaps := d.Get(optionDestroySnapshotsOnDelete).(bool)
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		if destroySnaps {
 			snapshots, _, err := client.SnapshotsApi.QuerySnapshots(ctx, &hmrest.SnapshotsApiQuerySnapshotsOpts{
 				ProtectionPolicyId: optional.NewString(d.Id()),
@@ -141,7 +141,7 @@ func (p *protectionPolicyProvider) PrepareDelete(ctx context.Context, client *hm
 	return fn, nil
 }
 
-func (p *protectionPolicyProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+func (p *protectionPolicyProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
 	if err := utilities.CheckImmutableFieldsExcept(ctx, d, optionDestroySnapshotsOnDelete); err != nil {
 		return nil, nil, err
 	}
@@ -149,7 +149,7 @@ func (p *protectionPolicyProvider) PrepareUpdate(ctx context.Context, client *hm
 	return DummyInvokeWriteAPI, []ResourcePatch{}, nil
 }
 
-func (p *protectionPolicyProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *protectionPolicyProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{resourceGroupNameProtectionPolicy}
 	// The ID is user provided value - we expect self link
 	selfLinkFieldsWithValues, err := utilities.ParseSelfLink(d.Id(), orderedRequiredGroupNames)
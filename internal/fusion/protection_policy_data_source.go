@@ -37,7 +37,7 @@ func dataSourceProtectionPolicy() *schema.Resource {
 	return protectionPolicyDataSourceFunctions.Resource
 }
 
-func (ds *protectionPolicyDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *protectionPolicyDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	resp, _, err := client.ProtectionPoliciesApi.ListProtectionPolicies(ctx, nil)
 	if err != nil {
 		return err
@@ -240,7 +240,7 @@ func testAccCheckProtectionPolicyExists(rName string) resource.TestCheckFunc {
 		policyName := savedPolicy["name"]
 		policyId := savedPolicy["id"]
 
-		foundPolicy, _, err := testAccProvider.Meta().(*hmrest.APIClient).ProtectionPoliciesApi.GetProtectionPolicyById(context.Background(), policyId, nil)
+		foundPolicy, _, err := testAccProvider.Meta().(*Client).ProtectionPoliciesApi.GetProtectionPolicyById(context.Background(), policyId, nil)
 		if err != nil {
 			return fmt.Errorf("go client returned error while searching for %s by id: %s. Error: %s", policyName, policyId, err)
 		}
@@ -282,7 +282,7 @@ func testAccCheckProtectionPolicyExists(rName string) resource.TestCheckFunc {
 }
 
 func testAccCheckProtectionPolicyDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_protection_policy" {
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -109,6 +110,22 @@ func Provider() *schema.Provider {
 				ValidateFunc: validation.StringIsNotEmpty,
 				Description:  "The password of encrypted RSA private key.",
 			},
+			optionMaxRetries: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      utilities.DefaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "How many times a request throttled (429) or failed (5xx) by the Fusion API is retried. " +
+					"Set to 0 to disable retrying.",
+			},
+			optionRetryMaxBackoff: {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          utilities.DefaultRetryMaxBackoff.String(),
+				ValidateDiagFunc: IsValidDuration,
+				Description: "The longest wait between two retries of a request, e.g. `30s` or `2m`. " +
+					"A longer wait requested by the API via the `Retry-After` header is still honored.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -128,35 +145,63 @@ func Provider() *schema.Provider {
 			"fusion_protection_policy":       resourceProtectionPolicy(),
 			"fusion_role_assignment":         resourceRoleAssignment(),
 			"fusion_network_interface":       resourceNetworkInterface(),
+			"fusion_snapshot":                resourceSnapshot(),
+			"fusion_volume_host_attachment":  resourceVolumeHostAttachment(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"fusion_storage_service":         dataSourceStorageService(),
-			"fusion_region":                  dataSourceRegion(),
-			"fusion_tenant":                  dataSourceTenant(),
-			"fusion_tenant_space":            dataSourceTenantSpace(),
-			"fusion_array":                   dataSourceArray(),
-			"fusion_storage_class":           dataSourceStorageClass(),
-			"fusion_network_interface_group": dataSourceNetworkInterfaceGroup(),
-			"fusion_hardware_type":           dataSourceHardwareType(),
-			"fusion_storage_endpoint":        dataSourceStorageEndpoint(),
-			"fusion_host_access_policy":      dataSourceHostAccessPolicy(),
-			"fusion_availability_zone":       dataSourceAvailabilityZone(),
-			"fusion_protection_policy":       dataSourceProtectionPolicy(),
-			"fusion_snapshot":                dataSourceSnapshot(),
-			"fusion_volume":                  dataSourceVolume(),
-			"fusion_role":                    dataSourceRole(),
-			"fusion_user":                    dataSourceUser(),
-			"fusion_volume_snapshot":         dataSourceVolumeSnapshot(),
-			"fusion_placement_group":         dataSourcePlacementGroup(),
-			"fusion_network_interface":       dataSourceNetworkInterface(),
+			"fusion_storage_service":               dataSourceStorageService(),
+			"fusion_region":                        dataSourceRegion(),
+			"fusion_tenant":                        dataSourceTenant(),
+			"fusion_tenant_space":                  dataSourceTenantSpace(),
+			"fusion_array":                         dataSourceArray(),
+			"fusion_storage_class":                 dataSourceStorageClass(),
+			"fusion_network_interface_group":       dataSourceNetworkInterfaceGroup(),
+			"fusion_hardware_type":                 dataSourceHardwareType(),
+			"fusion_storage_endpoint":              dataSourceStorageEndpoint(),
+			"fusion_host_access_policy":            dataSourceHostAccessPolicy(),
+			"fusion_availability_zone":             dataSourceAvailabilityZone(),
+			"fusion_protection_policy":             dataSourceProtectionPolicy(),
+			"fusion_snapshot":                      dataSourceSnapshot(),
+			"fusion_volume":                        dataSourceVolume(),
+			"fusion_role":                          dataSourceRole(),
+			"fusion_user":                          dataSourceUser(),
+			"fusion_volume_snapshot":               dataSourceVolumeSnapshot(),
+			"fusion_placement_group":               dataSourcePlacementGroup(),
+			"fusion_placement_recommendation":      dataSourcePlacementRecommendation(),
+			"fusion_network_interface":             dataSourceNetworkInterface(),
+			"fusion_volume_performance":            dataSourceVolumePerformance(),
+			"fusion_placement_group_performance":   dataSourcePlacementGroupPerformance(),
+			"fusion_tenant_space_performance":      dataSourceTenantSpacePerformance(),
+			"fusion_tenant_performance":            dataSourceTenantPerformance(),
+			"fusion_availability_zone_performance": dataSourceAvailabilityZonePerformance(),
+			"fusion_array_performance":             dataSourceArrayPerformance(),
+			"fusion_volume_capacity":               dataSourceVolumeCapacity(),
+			"fusion_placement_group_capacity":      dataSourcePlacementGroupCapacity(),
+			"fusion_tenant_space_capacity":         dataSourceTenantSpaceCapacity(),
+			"fusion_tenant_capacity":               dataSourceTenantCapacity(),
+			"fusion_availability_zone_capacity":    dataSourceAvailabilityZoneCapacity(),
+			"fusion_array_capacity":                dataSourceArrayCapacity(),
+			"fusion_capacity_report":               dataSourceCapacityReport(),
+			"fusion_placement_group_sessions":      dataSourcePlacementGroupSessions(),
+			"fusion_operations":                    dataSourceOperations(),
+			"fusion_version":                       dataSourceVersion(),
+			"fusion_volume_search":                 dataSourceVolumeSearch(),
+			"fusion_snapshot_search":               dataSourceSnapshotSearch(),
+			"fusion_placement_group_search":        dataSourcePlacementGroupSearch(),
+			"fusion_tenant_space_search":           dataSourceTenantSpaceSearch(),
+			"fusion_tenant_search":                 dataSourceTenantSearch(),
+			"fusion_region_search":                 dataSourceRegionSearch(),
 		},
 
-		ConfigureContextFunc: configureProvider,
+		ConfigureContextFunc: func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+			return configureProvider(ctx, d, http.DefaultTransport)
+		},
 	}
 }
 
-func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+// base is the http.RoundTripper sending the requests to Fusion, tests replace it to record and replay the traffic
+func configureProvider(ctx context.Context, d *schema.ResourceData, base http.RoundTripper) (interface{}, diag.Diagnostics) {
 	hostField := d.Get(optionHost).(string)
 	issuerIdField := d.Get(optionIssuerId).(string)
 	privateKeyField := d.Get(optionPrivateKey).(string)
@@ -166,6 +211,8 @@ func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}
 	accessTokenField := d.Get(optionAccessToken).(string)
 	configProfileField := d.Get(optionFusionConfigProfile).(string)
 	privateKeyPasswordKeyField := d.Get(optionPrivateKeyPassword).(string)
+	maxRetriesField := d.Get(optionMaxRetries).(int)
+	retryMaxBackoffField := d.Get(optionRetryMaxBackoff).(string)
 
 	configPathEnv := os.Getenv(fusionConfigVar)
 	tokenEndpointEnv := os.Getenv(auth.AuthNEndpointOverrideEnvVarName)
@@ -216,16 +263,22 @@ func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}
 		return nil, diag.Errorf("no Fusion host specified")
 	}
 
+	retryMaxBackoff, err := time.ParseDuration(retryMaxBackoffField)
+	if err != nil {
+		return nil, diag.Errorf("invalid %s: %s", optionRetryMaxBackoff, err)
+	}
+	transport := utilities.NewRetryTransport(base, maxRetriesField, retryMaxBackoff)
+
 	accessToken, issuerId, _ := getMostPrioritisedParameter(ctx,
 		[]string{accessTokenField, accessTokenEnv, fusionProfileConfig.AccessToken},
 		[]string{issuerIdField, issuerIdEnv, fusionProfileConfig.IssuerId},
 		optionAccessToken, optionIssuerId)
 	if accessToken != "" {
-		client, err := NewHMClientWithAccessToken(ctx, host, accessToken)
+		client, err := NewHMClientWithAccessToken(ctx, host, accessToken, transport)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
-		return client, nil
+		return newConfiguredClient(ctx, client), nil
 	}
 	if issuerId == "" {
 		return nil, diag.Errorf(
@@ -252,11 +305,11 @@ func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}
 		privateKeyPassword = getOption(ctx, optionPrivateKeyPassword, []string{privateKeyPasswordKeyField, privateKeyPasswordKeyEnv, fusionProfileConfig.PrivateKeyPassword})
 	}
 
-	client, err := NewHMClient(ctx, host, issuerId, privateKey, tokenEndpoint, privateKeyPassword)
+	client, err := NewHMClient(ctx, host, issuerId, privateKey, tokenEndpoint, privateKeyPassword, transport)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
-	return client, nil
+	return newConfiguredClient(ctx, client), nil
 }
 
 func logOptionUsage(ctx context.Context, position int, optionName string) {
@@ -305,12 +358,12 @@ func getOption(ctx context.Context, optionName string, priorities []string) stri
 	return ""
 }
 
-func getAccessToken(ctx context.Context, issuerId, privateKey, tokenEndpoint, privateKeyPassword string) (string, error) {
-	var accessToken string
+func getToken(ctx context.Context, issuerId, privateKey, tokenEndpoint, privateKeyPassword string) (*oauth2.Token, error) {
+	var token *oauth2.Token
 
 	err := utilities.Retry(ctx, time.Millisecond*100, 0.7, 13, "pure1_token", func() (bool, error) {
-		t, err := auth.GetPure1SelfSignedAccessTokenGoodForOneHour(ctx, issuerId, privateKey, tokenEndpoint, privateKeyPassword)
-		accessToken = t
+		t, err := auth.GetPure1SelfSignedToken(ctx, issuerId, privateKey, tokenEndpoint, privateKeyPassword)
+		token = t
 		var oauthErr *oauth2.RetrieveError
 		if errors.As(err, &oauthErr) {
 			c := oauthErr.Response.StatusCode
@@ -328,24 +381,36 @@ func getAccessToken(ctx context.Context, issuerId, privateKey, tokenEndpoint, pr
 	if err != nil {
 		utilities.TraceError(ctx, err)
 		tflog.Error(ctx, "Error getting API token", "error", err)
-		return "", err
+		return nil, err
 	}
 
-	return accessToken, nil
+	return token, nil
 }
 
-func NewHMClient(ctx context.Context, host, issuerId, privateKey, tokenEndpoint, privateKeyPassword string) (*hmrest.APIClient, error) {
+// transport is the http.RoundTripper used to send the authorized requests, e.g. one retrying failed requests
+func NewHMClient(ctx context.Context, host, issuerId, privateKey, tokenEndpoint, privateKeyPassword string, transport http.RoundTripper) (*hmrest.APIClient, error) {
 	tflog.Debug(ctx, "Using Fusion", optionHost, host)
-	accessToken, err := getAccessToken(ctx, issuerId, privateKey, tokenEndpoint, privateKeyPassword)
-	if err != nil {
+
+	// The access token is good for one hour only, so keep minting new ones for long-running applies
+	tokenSource := auth.NewRefreshingTokenSource(func(ctx context.Context) (*oauth2.Token, error) {
+		tflog.Debug(ctx, "Retrieving a new API token")
+		return getToken(ctx, issuerId, privateKey, tokenEndpoint, privateKeyPassword)
+	}, auth.DefaultRefreshBeforeExpiry)
+
+	// Fail early when the credentials are wrong
+	if _, err := tokenSource.TokenWithContext(ctx); err != nil {
 		return nil, err
 	}
 
 	tflog.Debug(ctx, "API token has been successfully retrieved")
-	return NewHMClientWithAccessToken(ctx, host, accessToken)
+	return newHMClientWithTokenSource(host, tokenSource, transport)
 }
 
-func NewHMClientWithAccessToken(ctx context.Context, host, accessToken string) (*hmrest.APIClient, error) {
+func NewHMClientWithAccessToken(ctx context.Context, host, accessToken string, transport http.RoundTripper) (*hmrest.APIClient, error) {
+	return newHMClientWithTokenSource(host, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken, TokenType: "Bearer"}), transport)
+}
+
+func newHMClientWithTokenSource(host string, tokenSource oauth2.TokenSource, transport http.RoundTripper) (*hmrest.APIClient, error) {
 	url, err := url.Parse(host)
 	if err != nil {
 		return nil, err
@@ -354,7 +419,10 @@ func NewHMClientWithAccessToken(ctx context.Context, host, accessToken string) (
 
 	return hmrest.NewAPIClient(&hmrest.Configuration{
 		BasePath:      url.String(),
-		DefaultHeader: map[string]string{"Authorization": "Bearer " + accessToken},
+		DefaultHeader: map[string]string{},
 		UserAgent:     fmt.Sprintf("terraform-provider-fusion/%s", providerVersion),
+		HTTPClient: &http.Client{
+			Transport: &auth.Transport{Source: tokenSource, Base: utilities.NewRequestIdTransport(transport)},
+		},
 	}), nil
 }
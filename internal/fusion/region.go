@@ -60,14 +60,14 @@ func (vp *regionProvider) PrepareCreate(ctx context.Context, d *schema.ResourceD
 		DisplayName: displayName,
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.RegionsApi.CreateRegion(ctx, *body.(*hmrest.RegionPost), nil)
 		return &op, err
 	}
 	return fn, &body, nil
 }
 
-func (vp *regionProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (vp *regionProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	region, _, err := client.RegionsApi.GetRegionById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -76,17 +76,17 @@ func (vp *regionProvider) ReadResource(ctx context.Context, client *hmrest.APICl
 	return vp.loadRegion(region, d)
 }
 
-func (vp *regionProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (vp *regionProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	name := rdString(ctx, d, "name")
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.RegionsApi.DeleteRegion(ctx, name, nil)
 		return &op, err
 	}
 	return fn, nil
 }
 
-func (vp *regionProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+func (vp *regionProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
 	var patches []ResourcePatch
 
 	regionName := rdString(ctx, d, "name")
@@ -101,7 +101,7 @@ func (vp *regionProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIC
 		})
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.RegionsApi.UpdateRegion(ctx, *body.(*hmrest.RegionPatch), regionName, nil)
 		return &op, err
 	}
@@ -109,7 +109,7 @@ func (vp *regionProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIC
 	return fn, patches, nil
 }
 
-func (vp *regionProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (vp *regionProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{resourceGroupNameRegion}
 	// The ID is user provided value - we expect self link
 	selfLinkFieldsWithValues, err := utilities.ParseSelfLink(d.Id(), orderedRequiredGroupNames)
@@ -9,6 +9,7 @@ import (
 	context "context"
 
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+	"github.com/antihax/optional"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
@@ -21,6 +22,8 @@ func dataSourceRegion() *schema.Resource {
 	ds := &regionDataSource{}
 
 	dsSchema := map[string]*schema.Schema{
+		optionName:        schemaListMatch("Only the Region with this name."),
+		optionDisplayName: schemaListMatch("Only the Regions with this display name."),
 		optionItems: {
 			Type:     schema.TypeList,
 			Computed: true,
@@ -30,28 +33,52 @@ func dataSourceRegion() *schema.Resource {
 			Description: "List of matching Regions.",
 		},
 	}
+	withListOptions(dsSchema, "Regions")
 
 	regionDataSourceFunctions := NewBaseDataSourceFunctions(resourceKindRegion, ds, dsSchema)
 
 	return regionDataSourceFunctions.Resource
 }
 
-func (ds *regionDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
-	resp, _, err := client.RegionsApi.ListRegions(ctx, nil)
+func (ds *regionDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	listOpts := rdListOptions(d)
+
+	opts := hmrest.RegionsApiListRegionsOpts{
+		Filter:      optionalString(listOpts.filter),
+		Sort:        optionalString(listOpts.sort),
+		Name:        optionalString(rdString(ctx, d, optionName)),
+		DisplayName: optionalString(rdString(ctx, d, optionDisplayName)),
+	}
+
+	var regions []hmrest.Region
+	moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		opts.Limit = optional.NewInt32(limit)
+		opts.Offset = optional.NewInt32(offset)
+		resp, _, err := client.RegionsApi.ListRegions(ctx, &opts)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		regions = append(regions, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	regionList := make([]map[string]interface{}, 0, resp.Count)
+	regionList := make([]map[string]interface{}, 0, len(regions))
 
-	for _, region := range resp.Items {
+	for _, region := range regions {
 		regionList = append(regionList, map[string]interface{}{
 			optionName:        region.Name,
 			optionDisplayName: region.DisplayName,
 		})
 	}
 
-	if err := d.Set(optionItems, regionList); err != nil {
+	err = getFirstError(
+		d.Set(optionItems, regionList),
+		d.Set(optionMoreItemsRemaining, moreItemsRemaining),
+	)
+	if err != nil {
 		return err
 	}
 
@@ -17,8 +17,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 // Creates and destroys
@@ -217,7 +215,7 @@ func testRegionExists(rName string) resource.TestCheckFunc {
 		}
 		attrs := tfRegion.Primary.Attributes
 
-		goclientRegion, _, err := testAccProvider.Meta().(*hmrest.APIClient).RegionsApi.GetRegionById(context.Background(), attrs["id"], nil)
+		goclientRegion, _, err := testAccProvider.Meta().(*Client).RegionsApi.GetRegionById(context.Background(), attrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client returned error while searching for %s by id: %s. Error: %s", attrs["name"], attrs["id"], err)
 		}
@@ -230,7 +228,7 @@ func testRegionExists(rName string) resource.TestCheckFunc {
 }
 
 func testCheckRegionDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_region" {
@@ -8,12 +8,16 @@ package fusion
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
 	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -27,9 +31,9 @@ type ResourcePost interface { // could have: id, name
 }
 type RequestSpec interface{}
 
-// type InvokeReadMultiAPI func(ctx context.Context, client *hmrest.APIClient) (resource []interface{}, err error)
-// type InvokeReadSingleAPI func(ctx context.Context, client *hmrest.APIClient) (resource interface{}, err error)
-type InvokeWriteAPI func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (operation *hmrest.Operation, err error)
+// type InvokeReadMultiAPI func(ctx context.Context, client *Client) (resource []interface{}, err error)
+// type InvokeReadSingleAPI func(ctx context.Context, client *Client) (resource interface{}, err error)
+type InvokeWriteAPI func(ctx context.Context, client *Client, body RequestSpec) (operation *hmrest.Operation, err error)
 
 // This is what you need to implement as the owner of a resource. Use the BaseResourceFunctions to build a schema.
 type ResourceProvider interface {
@@ -38,17 +42,21 @@ type ResourceProvider interface {
 	PrepareCreate(ctx context.Context, d *schema.ResourceData) (fn InvokeWriteAPI, post ResourcePost, err error)
 
 	// ReadResource synchronously reads the resource via its REST API.
-	ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (err error)
+	ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) (err error)
 
 	// PrepareUpdate returns a function which will call the Update REST API on this object and return an operation.
 	// Invoke it with each of the patches.
-	PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (fn InvokeWriteAPI, patches []ResourcePatch, err error)
+	PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (fn InvokeWriteAPI, patches []ResourcePatch, err error)
 
 	// PrepareDelete returns a function which will call the Delete REST API on this object and return an operation. Invoke it.
-	PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (fn InvokeWriteAPI, err error)
+	PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (fn InvokeWriteAPI, err error)
 
 	// ResourceImporter is a function which is called when Terraform is importing a resource.
-	ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (ds []*schema.ResourceData, err error)
+	ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) (ds []*schema.ResourceData, err error)
+
+	// MinimumApiVersions returns the Fusion API version each attribute needs, for the attributes which
+	// older control planes do not support. Plans setting such attribute fail early on an older control plane.
+	MinimumApiVersions() map[string]string
 }
 
 // Actually, an empty implementation which returns "not implemented" errors. :-)
@@ -60,26 +68,45 @@ func (p *BaseResourceProvider) PrepareCreate(ctx context.Context, d *schema.Reso
 	return nil, nil, fmt.Errorf("unsupported operation: create %s", p.ResourceKind)
 }
 
-func (p *BaseResourceProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (err error) {
+func (p *BaseResourceProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) (err error) {
 	return fmt.Errorf("unsupported operation: read %s", p.ResourceKind)
 }
 
-func (p *BaseResourceProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (fn InvokeWriteAPI, patches []ResourcePatch, err error) {
+func (p *BaseResourceProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (fn InvokeWriteAPI, patches []ResourcePatch, err error) {
 	return nil, nil, fmt.Errorf("unsupported operation: update %s", p.ResourceKind)
 }
 
-func (p *BaseResourceProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (fn InvokeWriteAPI, err error) {
+func (p *BaseResourceProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (fn InvokeWriteAPI, err error) {
 	return nil, fmt.Errorf("unsupported operation: delete %s", p.ResourceKind)
 }
 
-func (p *BaseResourceProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (ds []*schema.ResourceData, err error) {
+func (p *BaseResourceProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) (ds []*schema.ResourceData, err error) {
 	return nil, fmt.Errorf("unsupported operation: import %s", p.ResourceKind)
 }
 
+func (p *BaseResourceProvider) MinimumApiVersions() map[string]string {
+	return nil
+}
+
 //
 // Resource functions internally implement the interface defined by Terraform.
 //
 
+// Operations on arrays can take a while, but we don't want to wait forever if one gets stuck.
+// Can be changed per resource with the timeouts block.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// When we stop waiting for a create operation (timeout, Ctrl-C, failed poll), the resource may still get created.
+// Such resource is stored in the state with this prefix and the operation ID instead of its real ID,
+// so the next refresh can look the operation up and adopt the created resource instead of orphaning it.
+// The create still fails, so Terraform taints the resource: the next apply replaces the adopted resource,
+// resourceDelete waits for the pending operation first when the refresh has not adopted it yet.
+const pendingOperationIdPrefix = "pending-operation:"
+
 // Implements interface to Terraform: resource-CRUD
 type BaseResourceFunctions struct {
 	*schema.Resource
@@ -96,6 +123,15 @@ func NewBaseResourceFunctions(resourceKind string, provider ResourceProvider) *B
 	result.Resource.Importer = &schema.ResourceImporter{
 		StateContext: result.resourceImport,
 	}
+	result.Resource.Timeouts = &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultCreateTimeout),
+		Update: schema.DefaultTimeout(defaultUpdateTimeout),
+		Delete: schema.DefaultTimeout(defaultDeleteTimeout),
+	}
+	result.Resource.CustomizeDiff = customdiff.All(
+		checkMinimumApiVersions(provider.MinimumApiVersions()),
+		result.checkDeletionProtection,
+	)
 	return result
 }
 
@@ -113,20 +149,19 @@ func (f *BaseResourceFunctions) resourceCreate(ctx context.Context, d *schema.Re
 	op, err := callAPI(ctx, client, body)
 	if err != nil {
 		utilities.TraceError(ctx, err)
-		return utilities.ProcessClientError(ctx, "create", err)
+		// Some resources wait for their create operation in callAPI, and may have been interrupted there
+		return append(f.processClientError(ctx, "create", err), f.recordPendingOperation(ctx, d, op)...)
 	}
 
 	// Wait on Operation
-	succeeded, err := utilities.WaitOnOperation(ctx, op, client) // updates op with latest
+	succeeded, err := utilities.WaitOnOperation(ctx, op, client.OperationsApi) // updates op with latest
 	if err != nil {
 		utilities.TraceError(ctx, err)
-		return utilities.ProcessClientError(ctx, "get wait status", err)
+		return append(f.processClientError(ctx, "get wait status", err), f.recordPendingOperation(ctx, d, op)...)
 	}
 
 	if !succeeded {
-		tflog.Error(ctx, "REST create failed", "error_message", op.Error_.Message,
-			"PureCode", op.Error_.PureCode, "HttpCode", op.Error_.HttpCode)
-		return diag.Errorf(op.Error_.Message)
+		return f.processClientError(ctx, "create", utilities.NewRestErrorFromOperation(op))
 	}
 
 	// succeeded!
@@ -137,9 +172,20 @@ func (f *BaseResourceFunctions) resourceCreate(ctx context.Context, d *schema.Re
 }
 
 func (f *BaseResourceFunctions) resourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client, _ := f.resourceBoilerplate(ctx, "Read", d, m)
+	client, ctx := f.resourceBoilerplate(ctx, "Read", d, m)
+
+	if opId, pending := pendingOperationId(d); pending {
+		op, _, err := client.OperationsApi.GetOperation(ctx, opId, nil)
+		if err != nil {
+			return f.processClientError(ctx, "read pending operation", err)
+		}
+		if adopted, err := adoptPendingOperation(ctx, &op, d); !adopted {
+			return diag.FromErr(err)
+		}
+	}
+
 	err := f.Provider.ReadResource(ctx, client, d)
-	return utilities.ProcessClientError(ctx, "read", err)
+	return f.processClientError(ctx, "read", err)
 }
 
 func (f *BaseResourceFunctions) resourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -154,7 +200,7 @@ func (f *BaseResourceFunctions) resourceUpdate(ctx context.Context, d *schema.Re
 	err = executePatches(ctx, callAPI, patches, client, "resourceUpdate")
 	if err != nil {
 		d.Partial(true)
-		return utilities.ProcessClientError(ctx, "resourceUpdate", err)
+		return f.processClientError(ctx, "resourceUpdate", err)
 	}
 
 	return f.resourceRead(ctx, d, m)
@@ -163,6 +209,23 @@ func (f *BaseResourceFunctions) resourceUpdate(ctx context.Context, d *schema.Re
 func (f *BaseResourceFunctions) resourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client, ctx := f.resourceBoilerplate(ctx, "Delete", d, m)
 
+	if f.deletionProtected(d) {
+		return diag.Errorf("cannot delete %s %s: deletion_protection is enabled. "+
+			"Set deletion_protection = false and apply before deleting it", f.ResourceKind, d.Get(optionName))
+	}
+
+	if opId, pending := pendingOperationId(d); pending {
+		// Let the create finish, so that there is something to delete
+		op := hmrest.Operation{Id: opId}
+		_, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
+		if err != nil {
+			return f.processClientError(ctx, "wait for pending operation", err)
+		}
+		if adopted, err := adoptPendingOperation(ctx, &op, d); !adopted {
+			return diag.FromErr(err)
+		}
+	}
+
 	callAPI, err := f.Provider.PrepareDelete(ctx, client, d)
 	if err != nil {
 		tflog.Error(ctx, "in compute delete or volume: REST DELETE volume failed", "error_message", err)
@@ -171,18 +234,16 @@ func (f *BaseResourceFunctions) resourceDelete(ctx context.Context, d *schema.Re
 
 	op, err := callAPI(ctx, client, nil) // no body for delete
 	if err != nil {
-		return utilities.ProcessClientError(ctx, "delete", err)
+		return f.processClientError(ctx, "delete", err)
 	}
 
-	succeeded, err := utilities.WaitOnOperation(ctx, op, client)
+	succeeded, err := utilities.WaitOnOperation(ctx, op, client.OperationsApi)
 	if err != nil {
-		return utilities.ProcessClientError(ctx, "get wait status", err)
+		return f.processClientError(ctx, "get wait status", err)
 	}
 
 	if !succeeded {
-		tflog.Error(ctx, "REST delete failed", "error_message", op.Error_.Message,
-			"PureCode", op.Error_.PureCode, "HttpCode", op.Error_.HttpCode)
-		return diag.Errorf(op.Error_.Message)
+		return f.processClientError(ctx, "delete", utilities.NewRestErrorFromOperation(op))
 	}
 
 	return nil
@@ -193,7 +254,57 @@ func (f *BaseResourceFunctions) resourceImport(ctx context.Context, d *schema.Re
 	return f.Provider.ImportResource(ctx, client, d)
 }
 
-func executePatches(ctx context.Context, fn InvokeWriteAPI, patches []ResourcePatch, client *hmrest.APIClient, opSource string) error {
+func pendingOperationId(d *schema.ResourceData) (string, bool) {
+	if !strings.HasPrefix(d.Id(), pendingOperationIdPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(d.Id(), pendingOperationIdPrefix), true
+}
+
+// Records the create operation we stopped waiting for in place of the ID of the resource it may still create.
+// Nothing is recorded when the ID of the created resource is already known, or when the operation failed.
+// Returns a warning about the replacement of the tainted resource, to go along with the error of the create.
+func (f *BaseResourceFunctions) recordPendingOperation(ctx context.Context, d *schema.ResourceData, op *hmrest.Operation) diag.Diagnostics {
+	if op == nil || op.Id == "" || op.Status == "Failed" || d.Id() != "" {
+		return nil
+	}
+	tflog.Warn(ctx, "recording pending create operation", "op_id", op.Id)
+	d.SetId(pendingOperationIdPrefix + op.Id)
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s recorded with its pending create operation %s", f.ResourceKind, op.Id),
+		Detail: "The next refresh adopts the resource the operation creates, or removes it from the state if the operation fails. " +
+			"Terraform marks the resource tainted, so the next apply deletes the adopted resource and creates it again.",
+	}}
+}
+
+// Replaces the pending operation ID with the ID of the created resource once the operation has succeeded.
+// Returns true if the resource exists and can be read. A failed operation removes the resource from the state.
+func adoptPendingOperation(ctx context.Context, op *hmrest.Operation, d *schema.ResourceData) (bool, error) {
+	switch op.Status {
+	case "Succeeded", "Completed":
+		if op.Result == nil || op.Result.Resource == nil || op.Result.Resource.Id == "" {
+			return false, fmt.Errorf("create operation %s succeeded without telling which resource it created, "+
+				"import the resource or remove it from the state", op.Id)
+		}
+		tflog.Info(ctx, "adopting resource created by pending operation", "op_id", op.Id, "resource_id", op.Result.Resource.Id)
+		d.SetId(op.Result.Resource.Id)
+		return true, nil
+	case "Failed":
+		message := "reason unknown"
+		if op.Error_ != nil {
+			message = op.Error_.Message
+		}
+		tflog.Warn(ctx, "pending create operation failed, nothing to adopt", "op_id", op.Id, "error_message", message)
+		d.SetId("")
+		return false, nil
+	default:
+		tflog.Info(ctx, "create operation still pending", "op_id", op.Id, "op_status", op.Status)
+		return false, nil
+	}
+}
+
+func executePatches(ctx context.Context, fn InvokeWriteAPI, patches []ResourcePatch, client *Client, opSource string) error {
 	// Start operations for each update
 	for i, p := range patches {
 		ctx := tflog.With(ctx, "patch_idx", i)
@@ -208,23 +319,48 @@ func executePatches(ctx context.Context, fn InvokeWriteAPI, patches []ResourcePa
 		// because there are certain patch operations that need to happen
 		// in order.  Later on we can get more clever and try to come up
 		// with patch groups that can be done in parallel together
-		succeeded, err := utilities.WaitOnOperation(ctx, op, client)
+		succeeded, err := utilities.WaitOnOperation(ctx, op, client.OperationsApi)
 		if err != nil {
 			return err
 		}
 		if !succeeded {
-			return fmt.Errorf("operation failed Message:%s ID:%s", op.Error_.Message, op.Id)
+			return utilities.NewRestErrorFromOperation(op)
 		}
 	}
 	return nil
 }
 
+// Converts the error to diagnostics, pointing them at the attribute of the resource the error is about.
+// Fusion field names which are not attributes of the resource are left out of the attribute path.
+func (f *BaseResourceFunctions) processClientError(ctx context.Context, op string, err error) diag.Diagnostics {
+	diags := utilities.ProcessClientError(ctx, op, err)
+	for i := range diags {
+		if !f.hasAttribute(diags[i].AttributePath) {
+			diags[i].AttributePath = nil
+		}
+	}
+	return diags
+}
+
+func (f *BaseResourceFunctions) hasAttribute(path cty.Path) bool {
+	if len(path) != 1 {
+		return false
+	}
+	attr, ok := path[0].(cty.GetAttrStep)
+	if !ok {
+		return false
+	}
+	_, ok = f.Resource.Schema[attr.Name]
+	return ok
+}
+
 // A function used at the top of each CRUD function to grab stuff we need. Belongs in resource_functions.
-func (f *BaseResourceFunctions) resourceBoilerplate(ctx context.Context, action string, d *schema.ResourceData, m interface{}) (*hmrest.APIClient, context.Context) {
+func (f *BaseResourceFunctions) resourceBoilerplate(ctx context.Context, action string, d *schema.ResourceData, m interface{}) (*Client, context.Context) {
 	ctx = tflog.With(ctx, "resource_kind", f.ResourceKind)
+	ctx = utilities.WithRequestIdRecorder(ctx)
 	tflog.Debug(ctx, "resource", "action", action, "state", d.State())
 
-	client := m.(*hmrest.APIClient)
+	client := clientFromMeta(m)
 
 	return client, ctx
 }
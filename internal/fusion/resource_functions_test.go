@@ -0,0 +1,255 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+type testPendingProvider struct {
+	BaseResourceProvider
+	readIds    []string
+	deletedIds []string
+	// Waits for the create operation like the volume does, instead of leaving it to the base resource
+	waitInCreate bool
+}
+
+func (p *testPendingProvider) PrepareCreate(ctx context.Context, d *schema.ResourceData) (InvokeWriteAPI, ResourcePost, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
+		op := &hmrest.Operation{Id: "op-1", Status: "Pending", RetryIn: 10}
+		if !p.waitInCreate {
+			return op, nil
+		}
+		if _, err := utilities.WaitOnOperation(ctx, op, client.OperationsApi); err != nil {
+			return op, err
+		}
+		return op, nil
+	}
+	return fn, nil, nil
+}
+
+func (p *testPendingProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	p.readIds = append(p.readIds, d.Id())
+	return nil
+}
+
+func (p *testPendingProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
+		p.deletedIds = append(p.deletedIds, d.Id())
+		return &hmrest.Operation{Id: "op-2", Status: "Succeeded"}, nil
+	}
+	return fn, nil
+}
+
+// Serves GET /operations/{id} with the operation in the given status
+func testPendingOperationClient(t *testing.T, status string) *Client {
+	return testOperationClient(t, status, true)
+}
+
+// Serves GET /operations/{id} with the operation in the given status, with or without its result or error
+func testOperationClient(t *testing.T, status string, withOutcome bool) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op := hmrest.Operation{Id: strings.TrimPrefix(r.URL.Path, "/operations/"), Status: status, RetryIn: 10}
+		switch {
+		case status == "Succeeded" && withOutcome:
+			op.Result = &hmrest.OperationResult{Resource: &hmrest.ResourceReference{Id: "res-1"}}
+		case status == "Failed" && withOutcome:
+			op.Error_ = &hmrest.ModelError{Message: "no space left"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(op)
+	}))
+	t.Cleanup(server.Close)
+
+	return newClient(hmrest.NewAPIClient(&hmrest.Configuration{BasePath: server.URL, DefaultHeader: map[string]string{}, HTTPClient: server.Client()}))
+}
+
+func testPendingResource(t *testing.T, id string) (*BaseResourceFunctions, *testPendingProvider, *schema.ResourceData) {
+	p := &testPendingProvider{BaseResourceProvider: BaseResourceProvider{ResourceKind: "TestResource"}}
+	f := NewBaseResourceFunctions("TestResource", p)
+	f.Resource.Schema = map[string]*schema.Schema{optionName: {Type: schema.TypeString, Optional: true}}
+	d := f.Resource.TestResourceData()
+	d.SetId(id)
+	return f, p, d
+}
+
+func TestResourceCreate_recordsPendingOperation(t *testing.T) {
+	for _, waitInCreate := range []bool{false, true} {
+		t.Run(fmt.Sprintf("waitInCreate=%v", waitInCreate), func(t *testing.T) {
+			client := testPendingOperationClient(t, "Running")
+			f, p, d := testPendingResource(t, "")
+			p.waitInCreate = waitInCreate
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			diags := f.resourceCreate(ctx, d, client)
+			if !diags.HasError() {
+				t.Fatalf("expected create to fail when the operation does not finish in time")
+			}
+			if len(diags) != 2 {
+				t.Fatalf("expected an error and a warning, got %v", diags)
+			}
+			if !strings.Contains(diags[0].Summary, "op-1") {
+				t.Errorf("expected the diagnostic to mention the operation, got %q", diags[0].Summary)
+			}
+			if diags[1].Severity != diag.Warning || !strings.Contains(diags[1].Detail, "tainted") {
+				t.Errorf("expected a warning about the tainted resource, got %v", diags[1])
+			}
+			if d.Id() != pendingOperationIdPrefix+"op-1" {
+				t.Errorf("expected pending operation to be recorded, got ID %q", d.Id())
+			}
+		})
+	}
+}
+
+func TestResourceRead_pendingOperationWithoutOutcome(t *testing.T) {
+	tests := []struct {
+		status     string
+		expectedId string
+		fails      bool
+	}{
+		{"Succeeded", pendingOperationIdPrefix + "op-1", true},
+		{"Failed", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status, func(t *testing.T) {
+			client := testOperationClient(t, test.status, false)
+			f, p, d := testPendingResource(t, pendingOperationIdPrefix+"op-1")
+
+			diags := f.resourceRead(context.Background(), d, client)
+			if diags.HasError() != test.fails {
+				t.Errorf("expected failure: %v, got %v", test.fails, diags)
+			}
+			if d.Id() != test.expectedId {
+				t.Errorf("expected ID %q, got %q", test.expectedId, d.Id())
+			}
+			if len(p.readIds) != 0 {
+				t.Errorf("expected no resource read, got reads: %v", p.readIds)
+			}
+		})
+	}
+}
+
+func TestResourceRead_pendingOperation(t *testing.T) {
+	tests := []struct {
+		status     string
+		expectedId string
+		read       bool
+	}{
+		{"Succeeded", "res-1", true},
+		{"Failed", "", false},
+		{"Running", pendingOperationIdPrefix + "op-1", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status, func(t *testing.T) {
+			client := testPendingOperationClient(t, test.status)
+			f, p, d := testPendingResource(t, pendingOperationIdPrefix+"op-1")
+
+			if diags := f.resourceRead(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if d.Id() != test.expectedId {
+				t.Errorf("expected ID %q, got %q", test.expectedId, d.Id())
+			}
+			if test.read != (len(p.readIds) == 1) {
+				t.Errorf("expected resource read: %v, got reads: %v", test.read, p.readIds)
+			}
+		})
+	}
+}
+
+// Replacing a tainted resource recorded with its create operation deletes the resource the operation created
+func TestResourceDelete_pendingOperation(t *testing.T) {
+	tests := []struct {
+		status     string
+		deletedIds []string
+	}{
+		{"Succeeded", []string{"res-1"}},
+		{"Failed", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status, func(t *testing.T) {
+			client := testPendingOperationClient(t, test.status)
+			f, p, d := testPendingResource(t, pendingOperationIdPrefix+"op-1")
+
+			if diags := f.resourceDelete(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if fmt.Sprint(p.deletedIds) != fmt.Sprint(test.deletedIds) {
+				t.Errorf("expected deletes %v, got %v", test.deletedIds, p.deletedIds)
+			}
+		})
+	}
+}
+
+func TestResourceCreate_failedOperation(t *testing.T) {
+	tests := []struct {
+		field        string
+		expectedPath cty.Path
+	}{
+		{"name", cty.GetAttrPath(optionName)},
+		{"size", nil}, // not an attribute of the test resource
+		{"", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.field, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				op := hmrest.Operation{
+					Id:          "op-1",
+					RequestId:   "req-1",
+					RequestType: "CreateTestResource",
+					Status:      "Failed",
+					Error_: &hmrest.ModelError{
+						Message:  "100% of the names are taken",
+						PureCode: "ALREADY_EXISTS",
+						HttpCode: http.StatusConflict,
+						Details:  map[string]string{"field": test.field},
+					},
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(op)
+			}))
+			t.Cleanup(server.Close)
+			client := newClient(hmrest.NewAPIClient(&hmrest.Configuration{BasePath: server.URL, DefaultHeader: map[string]string{}, HTTPClient: server.Client()}))
+			f, _, d := testPendingResource(t, "")
+
+			diags := f.resourceCreate(context.Background(), d, client)
+			if len(diags) != 1 {
+				t.Fatalf("expected one diagnostic, got %v", diags)
+			}
+			if diags[0].Summary != "100% of the names are taken" {
+				t.Errorf("expected the message as summary, got %q", diags[0].Summary)
+			}
+			for _, expected := range []string{"ALREADY_EXISTS", "409", "op-1", "req-1"} {
+				if !strings.Contains(diags[0].Detail, expected) {
+					t.Errorf("expected the detail to contain %q, got %q", expected, diags[0].Detail)
+				}
+			}
+			if !diags[0].AttributePath.Equals(test.expectedPath) {
+				t.Errorf("expected attribute path %#v, got %#v", test.expectedPath, diags[0].AttributePath)
+			}
+		})
+	}
+}
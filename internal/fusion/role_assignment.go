@@ -86,7 +86,7 @@ func (p *roleAssignmentProvider) PrepareCreate(ctx context.Context, d *schema.Re
 		Scope:     p.getScope(ctx, d),
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.RoleAssignmentsApi.CreateRoleAssignment(ctx, *body.(*hmrest.RoleAssignmentPost), roleName, nil)
 		return &op, err
 	}
@@ -94,7 +94,7 @@ func (p *roleAssignmentProvider) PrepareCreate(ctx context.Context, d *schema.Re
 	return fn, &body, nil
 }
 
-func (p *roleAssignmentProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (p *roleAssignmentProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	roleAssignment, _, err := client.RoleAssignmentsApi.GetRoleAssignmentById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -103,18 +103,18 @@ func (p *roleAssignmentProvider) ReadResource(ctx context.Context, client *hmres
 	return p.loadRoleAssignment(roleAssignment, d)
 }
 
-func (p *roleAssignmentProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (p *roleAssignmentProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	name := rdString(ctx, d, optionName)
 	roleName := rdString(ctx, d, optionRoleName)
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.RoleAssignmentsApi.DeleteRoleAssignment(ctx, roleName, name, nil)
 		return &op, err
 	}
 	return fn, nil
 }
 
-func (p *roleAssignmentProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *roleAssignmentProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{
 		resourceGroupNameRole,
 		resourceGroupNameRoleAssignment,
@@ -17,8 +17,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 // Creates and destroys
@@ -156,7 +154,7 @@ func testRoleAssignmentExists(rName string) resource.TestCheckFunc {
 		}
 		attrs := tfRoleAssignment.Primary.Attributes
 
-		goclientRoleAssignment, _, err := testAccProvider.Meta().(*hmrest.APIClient).RoleAssignmentsApi.GetRoleAssignmentById(context.Background(), attrs["id"], nil)
+		goclientRoleAssignment, _, err := testAccProvider.Meta().(*Client).RoleAssignmentsApi.GetRoleAssignmentById(context.Background(), attrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client returned error while searching for %s by id: %s. Error: %s", attrs["name"], attrs["id"], err)
 		}
@@ -171,7 +169,7 @@ func testRoleAssignmentExists(rName string) resource.TestCheckFunc {
 }
 
 func testCheckRoleAssignmentDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_role_assignment" {
@@ -70,7 +70,7 @@ func dataSourceRole() *schema.Resource {
 	return roleDataSourceFunctions.Resource
 }
 
-func (ds *roleDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *roleDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	var opts hmrest.RolesApiListRolesOpts
 
 	if scope, ok := d.GetOk(optionAssignableScope); ok {
@@ -0,0 +1,468 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Lists the matching items across the whole organization and flattens them
+type searchFunc func(ctx context.Context, client *Client, d *schema.ResourceData, listOpts listOptions) ([]map[string]interface{}, bool, error)
+
+// Implements DataSource
+type searchDataSource struct {
+	search searchFunc
+}
+
+// A data source searching for items of one kind through its Query API.
+// Unlike the list data sources, it needs no Tenant or Tenant Space, the items found tell where they are.
+func dataSourceSearch(resourceKind, items, idOption string, args, itemSchema map[string]*schema.Schema, search searchFunc) *schema.Resource {
+	ds := &searchDataSource{search: search}
+
+	dsSchema := map[string]*schema.Schema{
+		idOption:          schemaListMatch("Only the " + items + " with this ID."),
+		optionName:        schemaListMatch("Only the " + items + " with this name."),
+		optionDisplayName: schemaListMatch("Only the " + items + " with this display name."),
+		optionItems: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: itemSchema,
+			},
+			Description: "List of matching " + items + ".",
+		},
+	}
+	for option, optionSchema := range args {
+		dsSchema[option] = optionSchema
+	}
+	withListOptions(dsSchema, items)
+
+	itemSchema[optionId] = &schema.Schema{Type: schema.TypeString, Computed: true}
+	itemSchema[optionName] = &schema.Schema{Type: schema.TypeString, Computed: true}
+	itemSchema[optionDisplayName] = &schema.Schema{Type: schema.TypeString, Computed: true}
+
+	searchDataSourceFunctions := NewBaseDataSourceFunctions(resourceKind+"Search", ds, dsSchema)
+	searchDataSourceFunctions.Resource.Description = "Searches the whole organization for " + items + " matching the given parameters, " +
+		"without knowing their Tenant or Tenant Space up front."
+
+	return searchDataSourceFunctions.Resource
+}
+
+func schemaSearchComputed(valueType schema.ValueType, description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        valueType,
+		Computed:    true,
+		Description: description,
+	}
+}
+
+func dataSourceVolumeSearch() *schema.Resource {
+	args := map[string]*schema.Schema{
+		optionSerialNumber: {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ValidateFunc:  validation.StringIsNotEmpty,
+			ConflictsWith: []string{optionWwn},
+			Description: "Only the Volume with this serial number, in any case. " +
+				"The serial number is the last 24 hexadecimal digits of the NAA identifier the hosts see for the Volume.",
+		},
+		optionWwn: {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateFunc: func(value interface{}, key string) ([]string, []error) {
+				if _, err := volumeSerialNumberFromWwn(value.(string)); err != nil {
+					return nil, []error{err}
+				}
+				return nil, nil
+			},
+			ConflictsWith: []string{optionSerialNumber},
+			Description: "Only the Volume with this World Wide Name, as the hosts see it, in any case: " +
+				"e.g. `624a9370...`, the udev name `wwn-0x624a9370...` or the multipath WWID `3624a9370...`.",
+		},
+		optionDestroyed: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Only the destroyed Volumes if `true`, only the Volumes which are not destroyed if `false`.",
+		},
+	}
+	itemSchema := map[string]*schema.Schema{
+		optionTenant:           schemaSearchComputed(schema.TypeString, "The name of the Tenant of the Volume."),
+		optionTenantSpace:      schemaSearchComputed(schema.TypeString, "The name of the Tenant Space of the Volume."),
+		optionSerialNumber:     schemaSearchComputed(schema.TypeString, "The serial number of the Volume."),
+		optionWwn:              schemaSearchComputed(schema.TypeString, "The World Wide Name of the Volume the hosts see."),
+		optionSize:             schemaSearchComputed(schema.TypeInt, "The size of the Volume, in bytes."),
+		optionStorageClass:     schemaSearchComputed(schema.TypeString, "The name of the Storage Class of the Volume."),
+		optionPlacementGroup:   schemaSearchComputed(schema.TypeString, "The name of the Placement Group of the Volume."),
+		optionProtectionPolicy: schemaSearchComputed(schema.TypeString, "The name of the Protection Policy of the Volume."),
+		optionDestroyed:        schemaSearchComputed(schema.TypeBool, "True if the Volume is destroyed."),
+		optionCreatedAt:        schemaSearchComputed(schema.TypeInt, "The time the Volume was created, in milliseconds since the Unix epoch."),
+	}
+
+	return dataSourceSearch(resourceKindVolume, "Volumes", optionVolumeId, args, itemSchema,
+		func(ctx context.Context, client *Client, d *schema.ResourceData, listOpts listOptions) ([]map[string]interface{}, bool, error) {
+			// Fusion reports serial numbers in uppercase, hosts in lowercase
+			serialNumber := strings.ToUpper(rdString(ctx, d, optionSerialNumber))
+			if wwn := rdString(ctx, d, optionWwn); wwn != "" {
+				var err error
+				if serialNumber, err = volumeSerialNumberFromWwn(wwn); err != nil {
+					return nil, false, err
+				}
+			}
+
+			opts := hmrest.VolumesApiQueryVolumesOpts{
+				Filter:       optionalString(listOpts.filter),
+				Sort:         optionalString(listOpts.sort),
+				Id:           optionalString(rdString(ctx, d, optionVolumeId)),
+				Name:         optionalString(rdString(ctx, d, optionName)),
+				DisplayName:  optionalString(rdString(ctx, d, optionDisplayName)),
+				SerialNumber: optionalString(serialNumber),
+				Destroyed:    rdOptionalBool(d, optionDestroyed),
+			}
+
+			var volumes []hmrest.Volume
+			moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+				opts.Limit = optional.NewInt32(limit)
+				opts.Offset = optional.NewInt32(offset)
+				resp, _, err := client.VolumesApi.QueryVolumes(ctx, &opts)
+				if err != nil {
+					return 0, 0, false, err
+				}
+				volumes = append(volumes, resp.Items...)
+				return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+			})
+			if err != nil {
+				return nil, false, err
+			}
+
+			volumeList := make([]map[string]interface{}, 0, len(volumes))
+			for _, vol := range volumes {
+				volInfo := map[string]interface{}{
+					optionId:           vol.Id,
+					optionName:         vol.Name,
+					optionDisplayName:  vol.DisplayName,
+					optionSerialNumber: vol.SerialNumber,
+					optionWwn:          volumeWwn(vol.SerialNumber),
+					optionSize:         vol.Size,
+					optionDestroyed:    vol.Destroyed,
+					optionCreatedAt:    vol.CreatedAt,
+				}
+				if vol.Tenant != nil {
+					volInfo[optionTenant] = vol.Tenant.Name
+				}
+				if vol.TenantSpace != nil {
+					volInfo[optionTenantSpace] = vol.TenantSpace.Name
+				}
+				if vol.StorageClass != nil {
+					volInfo[optionStorageClass] = vol.StorageClass.Name
+				}
+				if vol.PlacementGroup != nil {
+					volInfo[optionPlacementGroup] = vol.PlacementGroup.Name
+				}
+				if vol.ProtectionPolicy != nil {
+					volInfo[optionProtectionPolicy] = vol.ProtectionPolicy.Name
+				}
+				volumeList = append(volumeList, volInfo)
+			}
+
+			return volumeList, moreItemsRemaining, nil
+		})
+}
+
+func dataSourceSnapshotSearch() *schema.Resource {
+	args := map[string]*schema.Schema{
+		optionDestroyed: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Only the destroyed Snapshots if `true`, only the Snapshots which are not destroyed if `false`.",
+		},
+	}
+	itemSchema := map[string]*schema.Schema{
+		optionTenant:           schemaSearchComputed(schema.TypeString, "The name of the Tenant of the Snapshot."),
+		optionTenantSpace:      schemaSearchComputed(schema.TypeString, "The name of the Tenant Space of the Snapshot."),
+		optionProtectionPolicy: schemaSearchComputed(schema.TypeString, "The name of the Protection Policy which created the Snapshot."),
+		optionDestroyed:        schemaSearchComputed(schema.TypeBool, "True if the Snapshot is destroyed."),
+		optionTimeRemaining:    schemaSearchComputed(schema.TypeInt, "The number of milliseconds left before a destroyed Snapshot is eradicated."),
+	}
+
+	return dataSourceSearch(resourceKindSnapshot, "Snapshots", optionSnapshotId, args, itemSchema,
+		func(ctx context.Context, client *Client, d *schema.ResourceData, listOpts listOptions) ([]map[string]interface{}, bool, error) {
+			opts := hmrest.SnapshotsApiQuerySnapshotsOpts{
+				Filter:      optionalString(listOpts.filter),
+				Sort:        optionalString(listOpts.sort),
+				Id:          optionalString(rdString(ctx, d, optionSnapshotId)),
+				Name:        optionalString(rdString(ctx, d, optionName)),
+				DisplayName: optionalString(rdString(ctx, d, optionDisplayName)),
+				Destroyed:   rdOptionalBool(d, optionDestroyed),
+			}
+
+			var snapshots []hmrest.Snapshot
+			moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+				opts.Limit = optional.NewInt32(limit)
+				opts.Offset = optional.NewInt32(offset)
+				resp, _, err := client.SnapshotsApi.QuerySnapshots(ctx, &opts)
+				if err != nil {
+					return 0, 0, false, err
+				}
+				snapshots = append(snapshots, resp.Items...)
+				return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+			})
+			if err != nil {
+				return nil, false, err
+			}
+
+			snapshotList := make([]map[string]interface{}, 0, len(snapshots))
+			for _, snapshot := range snapshots {
+				snapshotInfo := map[string]interface{}{
+					optionId:            snapshot.Id,
+					optionName:          snapshot.Name,
+					optionDisplayName:   snapshot.DisplayName,
+					optionDestroyed:     snapshot.Destroyed,
+					optionTimeRemaining: snapshot.TimeRemaining,
+				}
+				if snapshot.Tenant != nil {
+					snapshotInfo[optionTenant] = snapshot.Tenant.Name
+				}
+				if snapshot.TenantSpace != nil {
+					snapshotInfo[optionTenantSpace] = snapshot.TenantSpace.Name
+				}
+				if snapshot.ProtectionPolicy != nil {
+					snapshotInfo[optionProtectionPolicy] = snapshot.ProtectionPolicy.Name
+				}
+				snapshotList = append(snapshotList, snapshotInfo)
+			}
+
+			return snapshotList, moreItemsRemaining, nil
+		})
+}
+
+func dataSourcePlacementGroupSearch() *schema.Resource {
+	args := map[string]*schema.Schema{
+		optionIqn:              schemaListMatch("Only the Placement Group with this iSCSI qualified name (IQN)."),
+		optionRegion:           schemaListMatch("Only the Placement Groups in the Region with this name."),
+		optionAvailabilityZone: schemaListMatch("Only the Placement Groups in the Availability Zones with this name."),
+		optionArray:            schemaListMatch("Only the Placement Groups on the Arrays with this name."),
+	}
+	itemSchema := map[string]*schema.Schema{
+		optionTenant:           schemaSearchComputed(schema.TypeString, "The name of the Tenant of the Placement Group."),
+		optionTenantSpace:      schemaSearchComputed(schema.TypeString, "The name of the Tenant Space of the Placement Group."),
+		optionAvailabilityZone: schemaSearchComputed(schema.TypeString, "The name of the Availability Zone of the Placement Group."),
+		optionStorageService:   schemaSearchComputed(schema.TypeString, "The name of the Storage Service of the Placement Group."),
+		optionArray:            schemaSearchComputed(schema.TypeString, "The name of the Array the Placement Group is placed on."),
+		optionIqn:              schemaSearchComputed(schema.TypeString, "The iSCSI qualified name (IQN) of the Placement Group."),
+	}
+
+	return dataSourceSearch(resourceKindPlacementGroup, "Placement Groups", optionPlacementGroupId, args, itemSchema,
+		func(ctx context.Context, client *Client, d *schema.ResourceData, listOpts listOptions) ([]map[string]interface{}, bool, error) {
+			opts := hmrest.PlacementGroupsApiQueryPlacementGroupsOpts{
+				Filter:               optionalString(listOpts.filter),
+				Sort:                 optionalString(listOpts.sort),
+				Id:                   optionalString(rdString(ctx, d, optionPlacementGroupId)),
+				Name:                 optionalString(rdString(ctx, d, optionName)),
+				DisplayName:          optionalString(rdString(ctx, d, optionDisplayName)),
+				Iqn:                  optionalString(rdString(ctx, d, optionIqn)),
+				RegionName:           optionalString(rdString(ctx, d, optionRegion)),
+				AvailabilityZoneName: optionalString(rdString(ctx, d, optionAvailabilityZone)),
+				ArrayName:            optionalString(rdString(ctx, d, optionArray)),
+			}
+
+			var placementGroups []hmrest.PlacementGroup
+			moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+				opts.Limit = optional.NewInt32(limit)
+				opts.Offset = optional.NewInt32(offset)
+				resp, _, err := client.PlacementGroupsApi.QueryPlacementGroups(ctx, &opts)
+				if err != nil {
+					return 0, 0, false, err
+				}
+				placementGroups = append(placementGroups, resp.Items...)
+				return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+			})
+			if err != nil {
+				return nil, false, err
+			}
+
+			placementGroupList := make([]map[string]interface{}, 0, len(placementGroups))
+			for _, pg := range placementGroups {
+				pgInfo := map[string]interface{}{
+					optionId:          pg.Id,
+					optionName:        pg.Name,
+					optionDisplayName: pg.DisplayName,
+				}
+				if pg.Tenant != nil {
+					pgInfo[optionTenant] = pg.Tenant.Name
+				}
+				if pg.TenantSpace != nil {
+					pgInfo[optionTenantSpace] = pg.TenantSpace.Name
+				}
+				if pg.AvailabilityZone != nil {
+					pgInfo[optionAvailabilityZone] = pg.AvailabilityZone.Name
+				}
+				if pg.StorageService != nil {
+					pgInfo[optionStorageService] = pg.StorageService.Name
+				}
+				if pg.Array != nil {
+					pgInfo[optionArray] = pg.Array.Name
+				}
+				if pg.Protocols != nil && pg.Protocols.Iscsi != nil {
+					pgInfo[optionIqn] = pg.Protocols.Iscsi.Iqn
+				}
+				placementGroupList = append(placementGroupList, pgInfo)
+			}
+
+			return placementGroupList, moreItemsRemaining, nil
+		})
+}
+
+func dataSourceTenantSpaceSearch() *schema.Resource {
+	itemSchema := map[string]*schema.Schema{
+		optionTenant: schemaSearchComputed(schema.TypeString, "The name of the Tenant of the Tenant Space."),
+	}
+
+	return dataSourceSearch(resourceKindTenantSpace, "Tenant Spaces", optionTenantSpaceId, nil, itemSchema,
+		func(ctx context.Context, client *Client, d *schema.ResourceData, listOpts listOptions) ([]map[string]interface{}, bool, error) {
+			opts := hmrest.TenantSpacesApiQueryTenantSpacesOpts{
+				Filter:      optionalString(listOpts.filter),
+				Sort:        optionalString(listOpts.sort),
+				Id:          optionalString(rdString(ctx, d, optionTenantSpaceId)),
+				Name:        optionalString(rdString(ctx, d, optionName)),
+				DisplayName: optionalString(rdString(ctx, d, optionDisplayName)),
+			}
+
+			var tenantSpaces []hmrest.TenantSpace
+			moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+				opts.Limit = optional.NewInt32(limit)
+				opts.Offset = optional.NewInt32(offset)
+				resp, _, err := client.TenantSpacesApi.QueryTenantSpaces(ctx, &opts)
+				if err != nil {
+					return 0, 0, false, err
+				}
+				tenantSpaces = append(tenantSpaces, resp.Items...)
+				return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+			})
+			if err != nil {
+				return nil, false, err
+			}
+
+			tenantSpaceList := make([]map[string]interface{}, 0, len(tenantSpaces))
+			for _, ts := range tenantSpaces {
+				tsInfo := map[string]interface{}{
+					optionId:          ts.Id,
+					optionName:        ts.Name,
+					optionDisplayName: ts.DisplayName,
+				}
+				if ts.Tenant != nil {
+					tsInfo[optionTenant] = ts.Tenant.Name
+				}
+				tenantSpaceList = append(tenantSpaceList, tsInfo)
+			}
+
+			return tenantSpaceList, moreItemsRemaining, nil
+		})
+}
+
+func dataSourceTenantSearch() *schema.Resource {
+	return dataSourceSearch(resourceKindTenant, "Tenants", optionTenantId, nil, map[string]*schema.Schema{},
+		func(ctx context.Context, client *Client, d *schema.ResourceData, listOpts listOptions) ([]map[string]interface{}, bool, error) {
+			opts := hmrest.TenantsApiQueryTenantsOpts{
+				Filter:      optionalString(listOpts.filter),
+				Sort:        optionalString(listOpts.sort),
+				Id:          optionalString(rdString(ctx, d, optionTenantId)),
+				Name:        optionalString(rdString(ctx, d, optionName)),
+				DisplayName: optionalString(rdString(ctx, d, optionDisplayName)),
+			}
+
+			var tenants []hmrest.Tenant
+			moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+				opts.Limit = optional.NewInt32(limit)
+				opts.Offset = optional.NewInt32(offset)
+				resp, _, err := client.TenantsApi.QueryTenants(ctx, &opts)
+				if err != nil {
+					return 0, 0, false, err
+				}
+				tenants = append(tenants, resp.Items...)
+				return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+			})
+			if err != nil {
+				return nil, false, err
+			}
+
+			tenantList := make([]map[string]interface{}, 0, len(tenants))
+			for _, tenant := range tenants {
+				tenantList = append(tenantList, map[string]interface{}{
+					optionId:          tenant.Id,
+					optionName:        tenant.Name,
+					optionDisplayName: tenant.DisplayName,
+				})
+			}
+
+			return tenantList, moreItemsRemaining, nil
+		})
+}
+
+func dataSourceRegionSearch() *schema.Resource {
+	return dataSourceSearch(resourceKindRegion, "Regions", optionRegionId, nil, map[string]*schema.Schema{},
+		func(ctx context.Context, client *Client, d *schema.ResourceData, listOpts listOptions) ([]map[string]interface{}, bool, error) {
+			opts := hmrest.RegionsApiQueryRegionsOpts{
+				Filter:      optionalString(listOpts.filter),
+				Sort:        optionalString(listOpts.sort),
+				Id:          optionalString(rdString(ctx, d, optionRegionId)),
+				Name:        optionalString(rdString(ctx, d, optionName)),
+				DisplayName: optionalString(rdString(ctx, d, optionDisplayName)),
+			}
+
+			var regions []hmrest.Region
+			moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+				opts.Limit = optional.NewInt32(limit)
+				opts.Offset = optional.NewInt32(offset)
+				resp, _, err := client.RegionsApi.QueryRegions(ctx, &opts)
+				if err != nil {
+					return 0, 0, false, err
+				}
+				regions = append(regions, resp.Items...)
+				return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+			})
+			if err != nil {
+				return nil, false, err
+			}
+
+			regionList := make([]map[string]interface{}, 0, len(regions))
+			for _, region := range regions {
+				regionList = append(regionList, map[string]interface{}{
+					optionId:          region.Id,
+					optionName:        region.Name,
+					optionDisplayName: region.DisplayName,
+				})
+			}
+
+			return regionList, moreItemsRemaining, nil
+		})
+}
+
+func (ds *searchDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	items, moreItemsRemaining, err := ds.search(ctx, client, d, rdListOptions(d))
+	if err != nil {
+		return err
+	}
+
+	err = getFirstError(
+		d.Set(optionItems, items),
+		d.Set(optionMoreItemsRemaining, moreItemsRemaining),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(utilities.GetIdForDataSource())
+
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Serves the given Volume to any query, and records the query
+func testVolumeSearchClient(t *testing.T, volume hmrest.Volume) (*Client, *string) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resources/volumes" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		query = r.URL.RawQuery
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(hmrest.VolumeList{Count: 1, Items: []hmrest.Volume{volume}})
+	}))
+	t.Cleanup(server.Close)
+	client := newClient(hmrest.NewAPIClient(&hmrest.Configuration{BasePath: server.URL, DefaultHeader: map[string]string{}, HTTPClient: server.Client()}))
+	return client, &query
+}
+
+func TestVolumeSearchDataSource_bySerialNumber(t *testing.T) {
+	client, query := testVolumeSearchClient(t, hmrest.Volume{
+		Id:             "vol-id",
+		Name:           "vol1",
+		SerialNumber:   "8F3A1F5B4F9A4D1B00011C1F",
+		Size:           1048576,
+		Tenant:         &hmrest.TenantRef{Name: "tenant1"},
+		TenantSpace:    &hmrest.TenantSpaceRef{Name: "ts1"},
+		PlacementGroup: &hmrest.PlacementGroupRef{Name: "pg1"},
+	})
+
+	dataSource := dataSourceVolumeSearch()
+	d := dataSource.TestResourceData()
+	d.Set(optionSerialNumber, "8F3A1F5B4F9A4D1B00011C1F")
+
+	if err := dataSource.ReadContext(context.Background(), d, client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *query != "limit=100&offset=0&serial_number=8F3A1F5B4F9A4D1B00011C1F" {
+		t.Errorf("unexpected query %q", *query)
+	}
+	for option, expected := range map[string]string{
+		optionId:             "vol-id",
+		optionTenant:         "tenant1",
+		optionTenantSpace:    "ts1",
+		optionPlacementGroup: "pg1",
+		optionStorageClass:   "",
+		optionWwn:            "624a93708f3a1f5b4f9a4d1b00011c1f",
+	} {
+		if got := d.Get(optionItems + ".0." + option).(string); got != expected {
+			t.Errorf("expected %s %q, got %q", option, expected, got)
+		}
+	}
+}
+
+func TestVolumeSearchDataSource_asHostsSeeIt(t *testing.T) {
+	tests := []struct {
+		option string
+		value  string
+	}{
+		{optionSerialNumber, "8f3a1f5b4f9a4d1b00011c1f"},
+		{optionWwn, "624a93708f3a1f5b4f9a4d1b00011c1f"},
+		{optionWwn, "wwn-0x624a93708f3a1f5b4f9a4d1b00011c1f"},
+		{optionWwn, "0x624A93708F3A1F5B4F9A4D1B00011C1F"},
+		{optionWwn, "3624a93708f3a1f5b4f9a4d1b00011c1f"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			client, query := testVolumeSearchClient(t, hmrest.Volume{Id: "vol-id", SerialNumber: "8F3A1F5B4F9A4D1B00011C1F"})
+
+			dataSource := dataSourceVolumeSearch()
+			d := dataSource.TestResourceData()
+			d.Set(test.option, test.value)
+
+			if err := dataSource.ReadContext(context.Background(), d, client); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *query != "limit=100&offset=0&serial_number=8F3A1F5B4F9A4D1B00011C1F" {
+				t.Errorf("unexpected query %q", *query)
+			}
+		})
+	}
+}
+
+func TestVolumeSerialNumberFromWwn_invalid(t *testing.T) {
+	for _, wwn := range []string{"", "8f3a1f5b4f9a4d1b00011c1f", "624a93708f3a1f5b", "600a09808f3a1f5b4f9a4d1b00011c1f", "624a9370zf3a1f5b4f9a4d1b00011c1f"} {
+		if serialNumber, err := volumeSerialNumberFromWwn(wwn); err == nil {
+			t.Errorf("expected %q to be rejected, got serial number %q", wwn, serialNumber)
+		}
+	}
+}
+
+func TestAccTenantSearchDataSource_basic(t *testing.T) {
+	utilities.CheckTestSkip(t)
+
+	tenant := acctest.RandomWithPrefix("search-ds-test-tenant")
+
+	dataSource := fmt.Sprintf(`
+	data "fusion_tenant_search" "tenant" {
+		tenant_id = fusion_tenant.%[1]s.id
+	}
+	`, tenant)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testCheckTenantDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testTenantConfig(tenant, tenant, tenant) + dataSource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.fusion_tenant_search.tenant", "items.#", "1"),
+					resource.TestCheckResourceAttr("data.fusion_tenant_search.tenant", "items.0.name", tenant),
+					resource.TestCheckResourceAttrPair("data.fusion_tenant_search.tenant", "items.0.id", "fusion_tenant."+tenant, "id"),
+				),
+			},
+		},
+	})
+}
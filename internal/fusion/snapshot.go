@@ -9,13 +9,375 @@ import (
 	"context"
 	"fmt"
 
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/antihax/optional"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
-func deleteSnapshot(ctx context.Context, snapshot hmrest.Snapshot, client *hmrest.APIClient) error {
+// Implements ResourceProvider
+type snapshotProvider struct {
+	BaseResourceProvider
+}
+
+func schemaSnapshot() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		optionName: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Snapshot.",
+		},
+		optionDisplayName: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringLenBetween(1, maxDisplayName),
+			Description:  "The human-readable name of the Snapshot. If not provided, defaults to I(name).",
+		},
+		optionTenant: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Tenant.",
+		},
+		optionTenantSpace: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Tenant Space.",
+		},
+		optionVolumes: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			ForceNew: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			ExactlyOneOf: []string{optionVolumes, optionPlacementGroup},
+			Description:  "The names of the Volumes to take a consistent Snapshot of.",
+		},
+		optionPlacementGroup: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			ExactlyOneOf: []string{optionVolumes, optionPlacementGroup},
+			Description:  "The name of the Placement Group to take a Snapshot of.",
+		},
+		optionEradicateOnDelete: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Eradicate the Snapshot when the Snapshot is deleted.",
+		},
+		optionProtectionPolicy: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the Protection Policy which created the Snapshot, if any.",
+		},
+		optionDestroyed: {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the Snapshot is destroyed.",
+		},
+	}
+}
+
+// This is our entry point for the Snapshot resource
+func resourceSnapshot() *schema.Resource {
+	p := &snapshotProvider{BaseResourceProvider{ResourceKind: resourceKindSnapshot}}
+
+	snapshotResourceFunctions := NewBaseResourceFunctions(resourceKindSnapshot, p)
+	snapshotResourceFunctions.Resource.Description = "A Snapshot is a consistent point-in-time copy of a set of " +
+		"Volumes or of all the Volumes in a Placement Group. It consists of one Volume Snapshot per Volume."
+	snapshotResourceFunctions.Resource.Schema = schemaSnapshot()
+
+	return snapshotResourceFunctions.Resource
+}
+
+func (p *snapshotProvider) PrepareCreate(ctx context.Context, d *schema.ResourceData) (InvokeWriteAPI, ResourcePost, error) {
+	name := rdString(ctx, d, optionName)
+	tenantName := rdString(ctx, d, optionTenant)
+	tenantSpaceName := rdString(ctx, d, optionTenantSpace)
+
+	body := hmrest.SnapshotPost{
+		Name:           name,
+		DisplayName:    rdStringDefault(ctx, d, optionDisplayName, name),
+		PlacementGroup: rdString(ctx, d, optionPlacementGroup),
+	}
+	if _, ok := d.GetOk(optionVolumes); ok {
+		body.Volumes = rdStringSet(ctx, d, optionVolumes)
+	}
+
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
+		op, _, err := client.SnapshotsApi.CreateSnapshot(ctx, *body.(*hmrest.SnapshotPost), tenantName, tenantSpaceName, nil)
+		return &op, err
+	}
+	return fn, &body, nil
+}
+
+func (p *snapshotProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	snapshot, _, err := client.SnapshotsApi.GetSnapshotById(ctx, d.Id(), nil)
+	if err != nil {
+		return err
+	}
+
+	if err := p.loadSnapshot(snapshot, d); err != nil {
+		return err
+	}
+	return p.loadSnapshotSource(ctx, client, snapshot, d)
+}
+
+func (p *snapshotProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+	if err := utilities.CheckImmutableFieldsExcept(ctx, d, optionEradicateOnDelete); err != nil {
+		return nil, nil, err
+	}
+
+	return DummyInvokeWriteAPI, []ResourcePatch{}, nil
+}
+
+func (p *snapshotProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
+	name := rdString(ctx, d, optionName)
+	tenantName := rdString(ctx, d, optionTenant)
+	tenantSpaceName := rdString(ctx, d, optionTenantSpace)
+	eradicate := d.Get(optionEradicateOnDelete).(bool)
+
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
+		tflog.Trace(ctx, "destroying snapshot")
+		op, _, err := client.SnapshotsApi.UpdateSnapshot(ctx, hmrest.SnapshotPatch{
+			Destroyed: &hmrest.NullableBoolean{Value: true},
+		}, tenantName, tenantSpaceName, name, nil)
+
+		// Do not eradicate the snapshot - return the operation for patching the snapshot (destroyed=true)
+		if !eradicate {
+			return &op, err
+		}
+
+		utilities.TraceError(ctx, err)
+		if err != nil {
+			return &op, err
+		}
+
+		// Wait for patching the snapshot (destroyed=true)
+		succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
+		if err != nil {
+			return &op, err
+		}
+		if !succeeded {
+			tflog.Error(ctx, "failed destroying snapshot")
+			return &op, fmt.Errorf("failed destroying snapshot")
+		}
+		tflog.Trace(ctx, "done destroying snapshot")
+
+		op, _, err = client.SnapshotsApi.DeleteSnapshot(ctx, tenantName, tenantSpaceName, name, nil)
+		return &op, err
+	}
+	return fn, nil
+}
+
+func (p *snapshotProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+	var orderedRequiredGroupNames = []string{
+		resourceGroupNameTenant,
+		resourceGroupNameTenantSpace,
+		resourceGroupNameSnapshot,
+	}
+	// The ID is user provided value - we expect self link
+	selfLinkFieldsWithValues, err := utilities.ParseSelfLink(d.Id(), orderedRequiredGroupNames)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot import path. Expected path in format '/tenants/<tenant>/tenant-spaces/<tenant-space>/snapshots/<snapshot>'")
+	}
+
+	tenantName := selfLinkFieldsWithValues[resourceGroupNameTenant]
+	tenantSpaceName := selfLinkFieldsWithValues[resourceGroupNameTenantSpace]
+	snapshotName := selfLinkFieldsWithValues[resourceGroupNameSnapshot]
+
+	snapshot, _, err := client.SnapshotsApi.GetSnapshot(ctx, tenantName, tenantSpaceName, snapshotName, nil)
+	if err != nil {
+		utilities.TraceError(ctx, err)
+		return nil, err
+	}
+
+	if err := p.loadSnapshot(snapshot, d); err != nil {
+		return nil, err
+	}
+	if err := p.loadSnapshotSource(ctx, client, snapshot, d); err != nil {
+		return nil, err
+	}
+
+	d.SetId(snapshot.Id)
+
+	// Snapshot is not destroyed, no need to recover it
+	if !snapshot.Destroyed {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	if err := p.recoverSnapshot(ctx, snapshot, client, d); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// The Snapshot does not remember how it was requested, so describe it by the Volumes it holds.
+// Keeps describing it the way the configuration requested it. An import has no configuration to go by,
+// so it names the Placement Group only if the Snapshot holds all of its Volumes, and the Volumes otherwise.
+func (p *snapshotProvider) loadSnapshotSource(ctx context.Context, client *Client, snapshot hmrest.Snapshot, d *schema.ResourceData) error {
+	tenantName := snapshot.Tenant.Name
+	tenantSpaceName := snapshot.TenantSpace.Name
+
+	var volumeSnapshots []hmrest.VolumeSnapshot
+	_, err := listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		resp, _, err := client.VolumeSnapshotsApi.ListVolumeSnapshots(ctx, tenantName, tenantSpaceName, snapshot.Name,
+			&hmrest.VolumeSnapshotsApiListVolumeSnapshotsOpts{
+				Limit:  optional.NewInt32(limit),
+				Offset: optional.NewInt32(offset),
+			})
+		if err != nil {
+			return 0, 0, false, err
+		}
+		volumeSnapshots = append(volumeSnapshots, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		utilities.TraceError(ctx, err)
+		return err
+	}
+
+	requestedPlacementGroup := rdString(ctx, d, optionPlacementGroup) != ""
+	if requestedPlacementGroup && len(volumeSnapshots) == 0 {
+		return nil // the Placement Group had no Volumes to take a Snapshot of
+	}
+
+	volumes := volumeSnapshotsVolumes(volumeSnapshots)
+	placementGroup := volumeSnapshotsPlacementGroup(volumeSnapshots)
+
+	if _, requestedVolumes := d.GetOk(optionVolumes); !requestedPlacementGroup && !requestedVolumes && placementGroup != nil {
+		requestedPlacementGroup, err = snapshotHoldsPlacementGroup(ctx, client, tenantName, tenantSpaceName, placementGroup, volumes)
+		if err != nil {
+			return err
+		}
+	}
+
+	if requestedPlacementGroup && placementGroup != nil {
+		return getFirstError(
+			d.Set(optionPlacementGroup, placementGroup.Name),
+			d.Set(optionVolumes, nil),
+		)
+	}
+	return getFirstError(
+		d.Set(optionPlacementGroup, ""),
+		d.Set(optionVolumes, volumes),
+	)
+}
+
+// Whether the Snapshot holds all the Volumes in the Placement Group, so that taking a Snapshot of the Placement Group
+// would take the same Snapshot
+func snapshotHoldsPlacementGroup(
+	ctx context.Context, client *Client, tenantName, tenantSpaceName string, placementGroup *hmrest.PlacementGroupRef, snapshotVolumes []string,
+) (bool, error) {
+	held := make(map[string]bool, len(snapshotVolumes))
+	for _, volume := range snapshotVolumes {
+		held[volume] = true
+	}
+
+	holdsAll := true
+	_, err := listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		resp, _, err := client.VolumesApi.ListVolumes(ctx, tenantName, tenantSpaceName, &hmrest.VolumesApiListVolumesOpts{
+			PlacementGroupId: optional.NewString(placementGroup.Id),
+			Destroyed:        optional.NewBool(false),
+			Limit:            optional.NewInt32(limit),
+			Offset:           optional.NewInt32(offset),
+		})
+		if err != nil {
+			return 0, 0, false, err
+		}
+		for _, volume := range resp.Items {
+			holdsAll = holdsAll && held[volume.Name]
+		}
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		utilities.TraceError(ctx, err)
+		return false, err
+	}
+	return holdsAll, nil
+}
+
+// Returns the Placement Group all the Volume Snapshots were taken in, nil if there is no such single Placement Group
+func volumeSnapshotsPlacementGroup(volumeSnapshots []hmrest.VolumeSnapshot) *hmrest.PlacementGroupRef {
+	var placementGroup *hmrest.PlacementGroupRef
+	for _, volumeSnapshot := range volumeSnapshots {
+		if volumeSnapshot.PlacementGroup == nil {
+			return nil
+		}
+		if placementGroup != nil && placementGroup.Name != volumeSnapshot.PlacementGroup.Name {
+			return nil
+		}
+		placementGroup = volumeSnapshot.PlacementGroup
+	}
+	return placementGroup
+}
+
+func volumeSnapshotsVolumes(volumeSnapshots []hmrest.VolumeSnapshot) []string {
+	volumes := []string{}
+	for _, volumeSnapshot := range volumeSnapshots {
+		if volumeSnapshot.Volume != nil {
+			volumes = append(volumes, volumeSnapshot.Volume.Name)
+		}
+	}
+	return volumes
+}
+
+func (p *snapshotProvider) loadSnapshot(snapshot hmrest.Snapshot, d *schema.ResourceData) error {
+	err := getFirstError(
+		d.Set(optionName, snapshot.Name),
+		d.Set(optionDisplayName, snapshot.DisplayName),
+		d.Set(optionTenant, snapshot.Tenant.Name),
+		d.Set(optionTenantSpace, snapshot.TenantSpace.Name),
+		d.Set(optionDestroyed, snapshot.Destroyed),
+		d.Set(optionProtectionPolicy, nil),
+	)
+	if snapshot.ProtectionPolicy != nil {
+		err = getFirstError(err, d.Set(optionProtectionPolicy, snapshot.ProtectionPolicy.Name))
+	}
+	return err
+}
+
+func (p *snapshotProvider) recoverSnapshot(
+	ctx context.Context, snapshot hmrest.Snapshot, client *Client, d *schema.ResourceData,
+) error {
+	body := hmrest.SnapshotPatch{Destroyed: &hmrest.NullableBoolean{Value: false}}
+	op, _, err := client.SnapshotsApi.UpdateSnapshot(ctx, body, snapshot.Tenant.Name, snapshot.TenantSpace.Name, snapshot.Name, nil)
+	if err != nil {
+		utilities.TraceError(ctx, err)
+		return err
+	}
+
+	succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
+	if err != nil {
+		utilities.TraceError(ctx, err)
+		return err
+	}
+
+	if !succeeded {
+		return utilities.NewRestErrorFromOperation(&op)
+	}
+
+	return d.Set(optionDestroyed, false)
+}
+
+func deleteSnapshot(ctx context.Context, snapshot hmrest.Snapshot, client *Client) error {
 	patchBody := hmrest.SnapshotPatch{Destroyed: &hmrest.NullableBoolean{Value: true}}
 	op, _, err := client.SnapshotsApi.UpdateSnapshot(ctx, patchBody, snapshot.Tenant.Name, snapshot.TenantSpace.Name, snapshot.Name, nil)
 
@@ -24,13 +386,13 @@ func deleteSnapshot(ctx context.Context, snapshot hmrest.Snapshot, client *hmres
 		return err
 	}
 
-	succeeded, err := utilities.WaitOnOperation(ctx, &op, client)
+	succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
 	if err != nil {
 		return err
 	}
 
 	if !succeeded {
-		return fmt.Errorf("operation failed Message:%s ID:%s", op.Error_.Message, op.Id)
+		return utilities.NewRestErrorFromOperation(&op)
 	}
 
 	op, _, err = client.SnapshotsApi.DeleteSnapshot(ctx, snapshot.Tenant.Name, snapshot.TenantSpace.Name, snapshot.Name, nil)
@@ -40,24 +402,24 @@ func deleteSnapshot(ctx context.Context, snapshot hmrest.Snapshot, client *hmres
 		return err
 	}
 
-	succeeded, err = utilities.WaitOnOperation(ctx, &op, client)
+	succeeded, err = utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
 	if err != nil {
 		return err
 	}
 
 	if !succeeded {
-		return fmt.Errorf("operation failed Message:%s ID:%s", op.Error_.Message, op.Id)
+		return utilities.NewRestErrorFromOperation(&op)
 	}
 
 	return nil
 }
 
-func createSnapshot(ctx context.Context, snapshotPost *hmrest.SnapshotPost, tenant, tenantSpace string, client *hmrest.APIClient) error {
+func createSnapshot(ctx context.Context, snapshotPost *hmrest.SnapshotPost, tenant, tenantSpace string, client *Client) error {
 	op, _, err := client.SnapshotsApi.CreateSnapshot(ctx, *snapshotPost, tenant, tenantSpace, nil)
 	if err != nil {
 		return fmt.Errorf("cannot create snapshot %s", err)
 	}
-	ok, err := utilities.WaitOnOperation(ctx, &op, client)
+	ok, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
 	if err != nil {
 		return fmt.Errorf("cannot create snapshot %s", err)
 	}
@@ -67,11 +429,16 @@ func createSnapshot(ctx context.Context, snapshotPost *hmrest.SnapshotPost, tena
 	return nil
 }
 
-func deleteSnapshots(ctx context.Context, snapshots *hmrest.SnapshotList, client *hmrest.APIClient) {
+// Deletes all the snapshots, even if some of them fail. Returns the failures.
+func deleteSnapshots(ctx context.Context, snapshots *hmrest.SnapshotList, client *Client) error {
+	var errs *multierror.Error
 	for _, snap := range snapshots.Items {
 		tflog.Trace(ctx, "Deleting Snapshot", "name", snap.Name)
 
 		// Might be possible to run in goroutines
-		_ = deleteSnapshot(ctx, snap, client)
+		if err := deleteSnapshot(ctx, snap, client); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("deleting snapshot %s: %w", snap.Name, err))
+		}
 	}
+	return errs.ErrorOrNil()
 }
@@ -56,6 +56,13 @@ func dataSourceSnapshot() *schema.Resource {
 			ValidateFunc: validation.StringIsNotEmpty,
 			Description:  "ID of the Protection Policy.",
 		},
+		optionName:        schemaListMatch("Only the Snapshot with this name."),
+		optionDisplayName: schemaListMatch("Only the Snapshots with this display name."),
+		optionDestroyed: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Only the destroyed Snapshots if `true`, only the Snapshots which are not destroyed if `false`.",
+		},
 		optionItems: {
 			Type:     schema.TypeList,
 			Computed: true,
@@ -101,22 +108,29 @@ func dataSourceSnapshot() *schema.Resource {
 			Description: "List of matching Snapshots.",
 		},
 	}
+	withListOptions(dsSchema, "Snapshots")
 
 	snapshotDataSourceFunctions := NewBaseDataSourceFunctions(resourceKindSnapshot, ds, dsSchema)
-	// Override default description as there's no resource for this data source.
-	snapshotDataSourceFunctions.Resource.Description = "Provides details about any Snapshot matching the given parameters."
 
 	return snapshotDataSourceFunctions.Resource
 }
 
-func (ds *snapshotDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *snapshotDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	tenant, _ := d.Get(optionTenant).(string)
 	tenantSpace, _ := d.Get(optionTenantSpace).(string)
 	volume, _ := d.Get(optionVolume).(string)
 	placementGroup, _ := d.Get(optionPlacementGroup).(string)
 	protectionPolicyId, _ := d.Get(optionProtectionPolicyId).(string)
 
-	opts := hmrest.SnapshotsApiListSnapshotsOpts{}
+	listOpts := rdListOptions(d)
+
+	opts := hmrest.SnapshotsApiListSnapshotsOpts{
+		Filter:      optionalString(listOpts.filter),
+		Sort:        optionalString(listOpts.sort),
+		Name:        optionalString(rdString(ctx, d, optionName)),
+		DisplayName: optionalString(rdString(ctx, d, optionDisplayName)),
+		Destroyed:   rdOptionalBool(d, optionDestroyed),
+	}
 	if volume != "" {
 		opts.Volume = optional.NewString(volume)
 	}
@@ -127,14 +141,24 @@ func (ds *snapshotDataSource) ReadDataSource(ctx context.Context, client *hmrest
 		opts.ProtectionPolicyId = optional.NewString(protectionPolicyId)
 	}
 
-	resp, _, err := client.SnapshotsApi.ListSnapshots(ctx, tenant, tenantSpace, &opts)
+	var snapshots []hmrest.Snapshot
+	moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		opts.Limit = optional.NewInt32(limit)
+		opts.Offset = optional.NewInt32(offset)
+		resp, _, err := client.SnapshotsApi.ListSnapshots(ctx, tenant, tenantSpace, &opts)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		snapshots = append(snapshots, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	snapshotList := make([]map[string]interface{}, resp.Count)
+	snapshotList := make([]map[string]interface{}, len(snapshots))
 
-	for i, snapshot := range resp.Items {
+	for i, snapshot := range snapshots {
 		snapshotList[i] = map[string]interface{}{
 			optionName:        snapshot.Name,
 			optionDisplayName: snapshot.DisplayName,
@@ -150,7 +174,11 @@ func (ds *snapshotDataSource) ReadDataSource(ctx context.Context, client *hmrest
 		}
 	}
 
-	if err := d.Set(optionItems, snapshotList); err != nil {
+	err = getFirstError(
+		d.Set(optionItems, snapshotList),
+		d.Set(optionMoreItemsRemaining, moreItemsRemaining),
+	)
+	if err != nil {
 		return err
 	}
 
@@ -214,7 +214,7 @@ func testCreateSnapshotsWithVolumes(ctx context.Context, snapshotNames []string,
 			Name:    snapshotName,
 			Volumes: volumes,
 		}
-		err := createSnapshot(ctx, &postSnapshot, tenant, tenantSpace, client)
+		err := createSnapshot(ctx, &postSnapshot, tenant, tenantSpace, newClient(client))
 		if err != nil {
 			return []map[string]interface{}{}, err
 		}
@@ -238,7 +238,7 @@ func testCreateSnapshotsListWithPlacementGroup(ctx context.Context, snapshotName
 			Name:           snapshotName,
 			PlacementGroup: placementGroup,
 		}
-		err := createSnapshot(ctx, &postSnapshot, tenant, tenantSpace, client)
+		err := createSnapshot(ctx, &postSnapshot, tenant, tenantSpace, newClient(client))
 		if err != nil {
 			return []map[string]interface{}{}, err
 		}
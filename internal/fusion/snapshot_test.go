@@ -1,12 +1,18 @@
 package fusion
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/fakefusion"
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
@@ -172,3 +178,339 @@ func TestAccSnapshot_e2eDemoTest(t *testing.T) {
 		},
 	})
 }
+
+func TestAccSnapshot_basic(t *testing.T) {
+	utilities.CheckTestSkip(t)
+
+	eradicate := true
+	volState, commonConfig := generateVolumeTestConfigAndCommonTFConfig(&eradicate, []string{"flash-array-x"}, nil)
+	commonConfig += testVolumeConfig(volState)
+
+	volumeSnap := testSnapshot{
+		RName:       "volume_snapshot",
+		Name:        acctest.RandomWithPrefix("snap-vol"),
+		DisplayName: "volume snapshot",
+		Tenant:      volState.Tenant,
+		TenantSpace: volState.TenantSpace,
+		Volumes:     []string{volState.RName},
+		Eradicate:   true,
+	}
+
+	placementGroupSnap := testSnapshot{
+		RName:          "placement_group_snapshot",
+		Name:           acctest.RandomWithPrefix("snap-pg"),
+		DisplayName:    "placement group snapshot",
+		Tenant:         volState.Tenant,
+		TenantSpace:    volState.TenantSpace,
+		PlacementGroup: volState.PlacementGroup,
+		Eradicate:      true,
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testCheckSnapshotDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: commonConfig + testSnapshotConfig(volumeSnap) + testSnapshotConfig(placementGroupSnap),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSnapshotAttributes("fusion_snapshot."+volumeSnap.RName, volumeSnap),
+					resource.TestCheckResourceAttr("fusion_snapshot."+volumeSnap.RName, "volumes.#", "1"),
+					testSnapshotExists(t, "fusion_snapshot."+volumeSnap.RName),
+					testCheckSnapshotAttributes("fusion_snapshot."+placementGroupSnap.RName, placementGroupSnap),
+					testSnapshotExists(t, "fusion_snapshot."+placementGroupSnap.RName),
+				),
+			},
+			{
+				// Snapshots cannot be modified, changing the display name takes a new Snapshot
+				Config: commonConfig + testSnapshotConfig(volumeSnap) +
+					testSnapshotConfig(testSnapshot{
+						RName:          placementGroupSnap.RName,
+						Name:           placementGroupSnap.Name,
+						DisplayName:    "changed display name",
+						Tenant:         placementGroupSnap.Tenant,
+						TenantSpace:    placementGroupSnap.TenantSpace,
+						PlacementGroup: placementGroupSnap.PlacementGroup,
+						Eradicate:      true,
+					}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("fusion_snapshot."+placementGroupSnap.RName, "display_name", "changed display name"),
+					testSnapshotExists(t, "fusion_snapshot."+placementGroupSnap.RName),
+				),
+			},
+			{
+				// Changing what to take a Snapshot of takes a new Snapshot
+				Config: commonConfig + testSnapshotConfig(testSnapshot{
+					RName:          volumeSnap.RName,
+					Name:           volumeSnap.Name,
+					DisplayName:    volumeSnap.DisplayName,
+					Tenant:         volumeSnap.Tenant,
+					TenantSpace:    volumeSnap.TenantSpace,
+					PlacementGroup: volState.PlacementGroup,
+					Eradicate:      true,
+				}) + testSnapshotConfig(placementGroupSnap),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("fusion_snapshot."+volumeSnap.RName, "volumes.#", "0"),
+					resource.TestCheckResourceAttrSet("fusion_snapshot."+volumeSnap.RName, "placement_group"),
+					testSnapshotExists(t, "fusion_snapshot."+volumeSnap.RName),
+				),
+			},
+			{
+				Config: commonConfig + testSnapshotConfig(volumeSnap) + testSnapshotConfig(placementGroupSnap),
+			},
+		},
+	})
+}
+
+func TestAccSnapshot_recovery(t *testing.T) {
+	utilities.CheckTestSkip(t)
+
+	eradicate := true
+	volState, commonConfig := generateVolumeTestConfigAndCommonTFConfig(&eradicate, []string{"flash-array-x"}, nil)
+	commonConfig += testVolumeConfig(volState)
+	ctx := setupTestCtx(t)
+	hmClient := testAccPreCheckWithReturningClient(ctx, t)
+
+	snap := testSnapshot{
+		RName:          "test_snapshot",
+		Name:           acctest.RandomWithPrefix("snap"),
+		DisplayName:    "snapshot",
+		Tenant:         volState.Tenant,
+		TenantSpace:    volState.TenantSpace,
+		PlacementGroup: volState.PlacementGroup,
+		Eradicate:      true,
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testCheckSnapshotDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: commonConfig + testSnapshotConfig(snap),
+				Check:  testSnapshotExists(t, "fusion_snapshot."+snap.RName),
+			},
+			{
+				// Manually destroy the snapshot, so that it is recovered by the import
+				PreConfig: func() {
+					body := hmrest.SnapshotPatch{Destroyed: &hmrest.NullableBoolean{Value: true}}
+					testVolumeDoOperation(t, ctx, hmClient, "snapshot destroy")(
+						hmClient.SnapshotsApi.UpdateSnapshot(ctx, body, snap.Tenant, snap.TenantSpace, snap.Name, nil),
+					)
+				},
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("/tenants/%s/tenant-spaces/%s/snapshots/%s", snap.Tenant, snap.TenantSpace, snap.Name),
+				ResourceName:            "fusion_snapshot." + snap.RName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"eradicate_on_delete"},
+			},
+		},
+	})
+}
+
+// The Snapshot cannot be patched but for its destruction, changing anything else must take a new Snapshot
+func TestSnapshotSchema_forceNew(t *testing.T) {
+	for key, keySchema := range resourceSnapshot().Schema {
+		if keySchema.Computed && !keySchema.Optional || key == optionEradicateOnDelete {
+			continue
+		}
+		if !keySchema.ForceNew {
+			t.Errorf("expected %s to force a new Snapshot", key)
+		}
+	}
+}
+
+func TestVolumeSnapshotsPlacementGroup(t *testing.T) {
+	inPlacementGroup := func(name string) hmrest.VolumeSnapshot {
+		volumeSnapshot := hmrest.VolumeSnapshot{Volume: &hmrest.VolumeRef{Name: "vol-" + name}}
+		if name != "" {
+			volumeSnapshot.PlacementGroup = &hmrest.PlacementGroupRef{Name: name}
+		}
+		return volumeSnapshot
+	}
+	nameOf := func(placementGroup *hmrest.PlacementGroupRef) string {
+		if placementGroup == nil {
+			return ""
+		}
+		return placementGroup.Name
+	}
+
+	tests := []struct {
+		name            string
+		volumeSnapshots []hmrest.VolumeSnapshot
+		expected        string
+	}{
+		{"empty", nil, ""},
+		{"single placement group", []hmrest.VolumeSnapshot{inPlacementGroup("pg1"), inPlacementGroup("pg1")}, "pg1"},
+		{"several placement groups", []hmrest.VolumeSnapshot{inPlacementGroup("pg1"), inPlacementGroup("pg2")}, ""},
+		{"no placement group", []hmrest.VolumeSnapshot{inPlacementGroup("pg1"), inPlacementGroup("")}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := nameOf(volumeSnapshotsPlacementGroup(test.volumeSnapshots)); actual != test.expected {
+				t.Errorf("expected placement group %q, got %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSnapshotImport_fakeApi(t *testing.T) {
+	server := fakefusion.NewServer()
+	t.Cleanup(server.Close)
+	client := newClient(server.Client())
+	ctx := context.Background()
+
+	for _, body := range []struct {
+		collection string
+		fields     map[string]interface{}
+	}{
+		{"/tenants", map[string]interface{}{"name": "tenant1"}},
+		{"/tenants/tenant1/tenant-spaces", map[string]interface{}{"name": "ts1"}},
+		{"/storage-services", map[string]interface{}{"name": "ss1", "hardware_types": []interface{}{"flash-array-x"}}},
+		{"/storage-services/ss1/storage-classes", map[string]interface{}{"name": "sc1"}},
+		{"/tenants/tenant1/tenant-spaces/ts1/placement-groups", map[string]interface{}{
+			"name": "pg1", "region": fakefusion.DefaultRegion, "availability_zone": fakefusion.DefaultAvailabilityZone, "storage_service": "ss1",
+		}},
+		{"/tenants/tenant1/tenant-spaces/ts1/volumes", map[string]interface{}{
+			"name": "vol1", "size": 1048576, "storage_class": "sc1", "placement_group": "pg1",
+		}},
+		{"/tenants/tenant1/tenant-spaces/ts1/volumes", map[string]interface{}{
+			"name": "vol2", "size": 1048576, "storage_class": "sc1", "placement_group": "pg1",
+		}},
+		{"/tenants/tenant1/tenant-spaces/ts1/snapshots", map[string]interface{}{"name": "snap-pg", "placement_group": "pg1"}},
+		{"/tenants/tenant1/tenant-spaces/ts1/snapshots", map[string]interface{}{"name": "snap-vol", "volumes": []interface{}{"vol1"}}},
+	} {
+		if err := server.Seed(body.collection, body.fields); err != nil {
+			t.Fatalf("cannot seed %s: %s", body.collection, err)
+		}
+	}
+
+	tests := []struct {
+		snapshot       string
+		placementGroup string
+		volumes        []interface{}
+	}{
+		{"snap-pg", "pg1", []interface{}{}},
+		// Holds only some of the Volumes of its Placement Group, so taking a Snapshot of the Placement Group differs
+		{"snap-vol", "", []interface{}{"vol1"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.snapshot, func(t *testing.T) {
+			snapshot := resourceSnapshot()
+			d := snapshot.TestResourceData()
+			d.SetId("/tenants/tenant1/tenant-spaces/ts1/snapshots/" + test.snapshot)
+
+			imported, err := snapshot.Importer.StateContext(ctx, d, client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// Reads keep describing the Snapshot the way it was imported
+			if diags := snapshot.ReadContext(ctx, imported[0], client); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if placementGroup := imported[0].Get(optionPlacementGroup); placementGroup != test.placementGroup {
+				t.Errorf("expected placement group %q, got %q", test.placementGroup, placementGroup)
+			}
+			if volumes := imported[0].Get(optionVolumes).(*schema.Set).List(); !reflect.DeepEqual(volumes, test.volumes) {
+				t.Errorf("expected volumes %v, got %v", test.volumes, volumes)
+			}
+		})
+	}
+}
+
+type testSnapshot struct {
+	RName          string
+	Name           string
+	DisplayName    string
+	Tenant         string
+	TenantSpace    string
+	Volumes        []string // resource names of the volumes
+	PlacementGroup string   // resource name of the placement group
+	Eradicate      bool
+}
+
+func testSnapshotConfig(snap testSnapshot) string {
+	source := fmt.Sprintf("placement_group = fusion_placement_group.%s.name", snap.PlacementGroup)
+	if len(snap.Volumes) != 0 {
+		volumes := make([]string, len(snap.Volumes))
+		for i, volume := range snap.Volumes {
+			volumes[i] = fmt.Sprintf("fusion_volume.%s.name", volume)
+		}
+		source = fmt.Sprintf("volumes = [%s]", strings.Join(volumes, ","))
+	}
+
+	return fmt.Sprintf(`
+resource "fusion_snapshot" "%[1]s" {
+		name                = "%[2]s"
+		display_name        = "%[3]s"
+		tenant              = fusion_tenant.%[4]s.name
+		tenant_space        = fusion_tenant_space.%[5]s.name
+		%[6]s
+		eradicate_on_delete = %[7]t
+}`, snap.RName, snap.Name, snap.DisplayName, snap.Tenant, snap.TenantSpace, source, snap.Eradicate)
+}
+
+func testCheckSnapshotAttributes(resourceName string, snap testSnapshot) resource.TestCheckFunc {
+	return resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttr(resourceName, "name", snap.Name),
+		resource.TestCheckResourceAttr(resourceName, "display_name", snap.DisplayName),
+		resource.TestCheckResourceAttr(resourceName, "tenant", snap.Tenant),
+		resource.TestCheckResourceAttr(resourceName, "tenant_space", snap.TenantSpace),
+		resource.TestCheckResourceAttr(resourceName, "destroyed", "false"),
+	)
+}
+
+// Verify resource with a direct hmrest call
+func testSnapshotExists(t *testing.T, rName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		tfSnapshot, ok := s.RootModule().Resources[rName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rName)
+		}
+		if tfSnapshot.Type != "fusion_snapshot" {
+			return fmt.Errorf("expected type: fusion_snapshot. Found: %s", tfSnapshot.Type)
+		}
+		attrs := tfSnapshot.Primary.Attributes
+
+		snapshot, _, err := testAccProvider.Meta().(*Client).SnapshotsApi.GetSnapshotById(context.Background(), attrs["id"], nil)
+		if err != nil {
+			return fmt.Errorf("go client returned error while searching for %s by id: %s. Error: %s", attrs["name"], attrs["id"], err)
+		}
+
+		if !utilities.CheckStrAttribute(t, "name", snapshot.Name, attrs["name"]) ||
+			!utilities.CheckStrAttribute(t, "display_name", snapshot.DisplayName, attrs["display_name"]) ||
+			!utilities.CheckStrAttribute(t, "tenant", snapshot.Tenant.Name, attrs["tenant"]) ||
+			!utilities.CheckStrAttribute(t, "tenant_space", snapshot.TenantSpace.Name, attrs["tenant_space"]) ||
+			!utilities.CheckBoolAttribute(t, "destroyed", snapshot.Destroyed, attrs["destroyed"]) {
+			return fmt.Errorf("'fusion_snapshot' stored state in Terraform doesn't match reality")
+		}
+		return nil
+	}
+}
+
+func testCheckSnapshotDelete(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "fusion_snapshot" {
+			continue
+		}
+		attrs := rs.Primary.Attributes
+
+		snapshot, resp, err := client.SnapshotsApi.GetSnapshotById(context.Background(), attrs["id"], nil)
+		if resp == nil {
+			return fmt.Errorf("cannot check whether snapshot %s still exists: %s", attrs["name"], err)
+		}
+		if err != nil && resp.StatusCode == http.StatusNotFound {
+			continue // the snapshot was eradicated
+		}
+		if err == nil && snapshot.Destroyed && attrs["eradicate_on_delete"] != "true" {
+			continue // the snapshot was destroyed, but not eradicated
+		}
+
+		return fmt.Errorf("snapshot may still exist. Expected response code 404, got code %d", resp.StatusCode)
+	}
+	return testCheckVolumeDelete(s)
+}
@@ -116,14 +116,14 @@ func (p *storageClassProvider) PrepareCreate(ctx context.Context, d *schema.Reso
 		BandwidthLimit: bandwidthLimit,
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.StorageClassesApi.CreateStorageClass(ctx, *body.(*hmrest.StorageClassPost), storageService, nil)
 		return &op, err
 	}
 	return fn, &body, nil
 }
 
-func (p *storageClassProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (p *storageClassProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	sc, _, err := client.StorageClassesApi.GetStorageClassById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -132,7 +132,7 @@ func (p *storageClassProvider) ReadResource(ctx context.Context, client *hmrest.
 	return p.loadStorageClass(sc, d)
 }
 
-func (p *storageClassProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+func (p *storageClassProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
 	var patches []ResourcePatch
 
 	storageClassName := rdString(ctx, d, optionName)
@@ -148,7 +148,7 @@ func (p *storageClassProvider) PrepareUpdate(ctx context.Context, client *hmrest
 		DisplayName: &hmrest.NullableString{Value: displayName},
 	})
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.StorageClassesApi.UpdateStorageClass(ctx, *body.(*hmrest.StorageClassPatch), storageServiceName, storageClassName, nil)
 		return &op, err
 	}
@@ -156,18 +156,18 @@ func (p *storageClassProvider) PrepareUpdate(ctx context.Context, client *hmrest
 	return fn, patches, nil
 }
 
-func (p *storageClassProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (p *storageClassProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	storageClassName := rdString(ctx, d, optionName)
 	storageServiceName := rdString(ctx, d, optionStorageService)
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.StorageClassesApi.DeleteStorageClass(ctx, storageServiceName, storageClassName, nil)
 		return &op, err
 	}
 	return fn, nil
 }
 
-func (p *storageClassProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *storageClassProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{
 		resourceGroupNameStorageService,
 		resourceGroupNameStorageClass,
@@ -12,7 +12,6 @@ import (
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 // Implements DataSource
@@ -44,7 +43,7 @@ func dataSourceStorageClass() *schema.Resource {
 	return storageClassDataSourceFunctions.Resource
 }
 
-func (ds *storageClassDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *storageClassDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	resp, _, err := client.StorageClassesApi.ListStorageClasses(ctx, rdString(ctx, d, optionStorageService), nil)
 	if err != nil {
 		return err
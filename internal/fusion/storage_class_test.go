@@ -18,8 +18,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 var (
@@ -303,7 +301,7 @@ func testStorageClassExists(rName string) resource.TestCheckFunc {
 		}
 		attrs := tfStorageClass.Primary.Attributes
 
-		goclientStorageClass, _, err := testAccProvider.Meta().(*hmrest.APIClient).StorageClassesApi.GetStorageClassById(context.Background(), attrs["id"], nil)
+		goclientStorageClass, _, err := testAccProvider.Meta().(*Client).StorageClassesApi.GetStorageClassById(context.Background(), attrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client returned error while searching for %s by id: %s. Error: %s", attrs["name"], attrs["id"], err)
 		}
@@ -336,7 +334,7 @@ func testStorageClassExists(rName string) resource.TestCheckFunc {
 }
 
 func testCheckStorageClassDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_storage_class" {
@@ -159,7 +159,7 @@ func (p *storageEndpointProvider) PrepareCreate(ctx context.Context, d *schema.R
 		body.Iscsi = p.makeStorageEndpointIscsiPost(d)
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.StorageEndpointsApi.CreateStorageEndpoint(
 			ctx, *body.(*hmrest.StorageEndpointPost), region, availabilityZone, nil,
 		)
@@ -169,7 +169,7 @@ func (p *storageEndpointProvider) PrepareCreate(ctx context.Context, d *schema.R
 	return fn, &body, nil
 }
 
-func (p *storageEndpointProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (p *storageEndpointProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	se, _, err := client.StorageEndpointsApi.GetStorageEndpointById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -179,19 +179,19 @@ func (p *storageEndpointProvider) ReadResource(ctx context.Context, client *hmre
 
 }
 
-func (p *storageEndpointProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (p *storageEndpointProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	name := rdString(ctx, d, optionName)
 	region := rdString(ctx, d, optionRegion)
 	availabilityZone := rdString(ctx, d, optionAvailabilityZone)
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.StorageEndpointsApi.DeleteStorageEndpoint(ctx, region, availabilityZone, name, nil)
 		return &op, err
 	}
 	return fn, nil
 }
 
-func (p *storageEndpointProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+func (p *storageEndpointProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
 	var patches []ResourcePatch
 
 	name := rdString(ctx, d, optionName)
@@ -214,7 +214,7 @@ func (p *storageEndpointProvider) PrepareUpdate(ctx context.Context, client *hmr
 		DisplayName: &hmrest.NullableString{Value: displayName},
 	})
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.StorageEndpointsApi.UpdateStorageEndpoint(ctx, *body.(*hmrest.StorageEndpointPatch), region, availabilityZone, name, nil)
 		return &op, err
 	}
@@ -222,7 +222,7 @@ func (p *storageEndpointProvider) PrepareUpdate(ctx context.Context, client *hmr
 	return fn, patches, nil
 }
 
-func (p *storageEndpointProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *storageEndpointProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{
 		resourceGroupNameRegion,
 		resourceGroupNameAvailabilityZone,
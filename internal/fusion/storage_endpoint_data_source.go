@@ -12,7 +12,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 // Implements DataSource
@@ -54,7 +53,7 @@ func dataSourceStorageEndpoint() *schema.Resource {
 	return storageEndpointDataSourceFunctions.Resource
 }
 
-func (ds *storageEndpointDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *storageEndpointDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	resp, _, err := client.StorageEndpointsApi.ListStorageEndpoints(ctx, rdString(ctx, d, optionRegion), rdString(ctx, d, optionAvailabilityZone), nil)
 	if err != nil {
 		return err
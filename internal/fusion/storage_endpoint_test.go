@@ -332,7 +332,7 @@ func testStorageEndpointExistsBase(s *terraform.State, rName string) (*terraform
 	}
 
 	attrs := tfResource.Primary.Attributes
-	actualSe, _, err := testAccProvider.Meta().(*hmrest.APIClient).StorageEndpointsApi.GetStorageEndpointById(
+	actualSe, _, err := testAccProvider.Meta().(*Client).StorageEndpointsApi.GetStorageEndpointById(
 		context.Background(),
 		attrs["id"],
 		nil,
@@ -348,7 +348,7 @@ func testStorageEndpointExistsBase(s *terraform.State, rName string) (*terraform
 }
 
 func testCheckStorageEndpointDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_storage_endpoint" {
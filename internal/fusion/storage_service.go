@@ -81,14 +81,14 @@ func (vp *storageServiceProvider) PrepareCreate(ctx context.Context, d *schema.R
 		HardwareTypes: hardwareTypes,
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.StorageServicesApi.CreateStorageService(ctx, *body.(*hmrest.StorageServicePost), nil)
 		return &op, err
 	}
 	return fn, &body, nil
 }
 
-func (vp *storageServiceProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (vp *storageServiceProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	ss, _, err := client.StorageServicesApi.GetStorageServiceById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -97,17 +97,17 @@ func (vp *storageServiceProvider) ReadResource(ctx context.Context, client *hmre
 	return vp.loadStorageService(ss, d)
 }
 
-func (vp *storageServiceProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (vp *storageServiceProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	storageServiceName := rdString(ctx, d, "name")
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.StorageServicesApi.DeleteStorageService(ctx, storageServiceName, nil)
 		return &op, err
 	}
 	return fn, nil
 }
 
-func (vp *storageServiceProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+func (vp *storageServiceProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
 	var patches []ResourcePatch
 	storageServiceName := rdString(ctx, d, "name")
 
@@ -122,7 +122,7 @@ func (vp *storageServiceProvider) PrepareUpdate(ctx context.Context, client *hmr
 		})
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.StorageServicesApi.UpdateStorageService(ctx, *body.(*hmrest.StorageServicePatch), storageServiceName, nil)
 		return &op, err
 	}
@@ -130,7 +130,7 @@ func (vp *storageServiceProvider) PrepareUpdate(ctx context.Context, client *hmr
 	return fn, patches, nil
 }
 
-func (vp *storageServiceProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (vp *storageServiceProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{resourceGroupNameStorageService}
 	// The ID is user provided value - we expect self link
 	selfLinkFieldsWithValues, err := utilities.ParseSelfLink(d.Id(), orderedRequiredGroupNames)
@@ -10,7 +10,6 @@ import (
 
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 // Implements DataSource
@@ -36,7 +35,7 @@ func dataSourceStorageService() *schema.Resource {
 	return storageServiceDataSourceFunctions.Resource
 }
 
-func (ds *storageServiceDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *storageServiceDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	resp, _, err := client.StorageServicesApi.ListStorageServices(ctx, nil)
 	if err != nil {
 		return err
@@ -18,8 +18,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 // Creates and destroys
@@ -228,7 +226,7 @@ func testStorageServiceExists(rName string) resource.TestCheckFunc {
 		}
 		attrs := tfStorageService.Primary.Attributes
 
-		goclientStorageService, _, err := testAccProvider.Meta().(*hmrest.APIClient).StorageServicesApi.GetStorageServiceById(context.Background(), attrs["id"], nil)
+		goclientStorageService, _, err := testAccProvider.Meta().(*Client).StorageServicesApi.GetStorageServiceById(context.Background(), attrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client returned error while searching for %s by id: %s. Error: %s", attrs["name"], attrs["id"], err)
 		}
@@ -242,7 +240,7 @@ func testStorageServiceExists(rName string) resource.TestCheckFunc {
 }
 
 func testCheckStorageServiceDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_storage_service" {
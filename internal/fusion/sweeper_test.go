@@ -0,0 +1,676 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/antihax/optional"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/fakefusion"
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+)
+
+// The sweepers delete the objects failed acceptance test runs leave behind. Run them with
+//
+//	go test ./internal/fusion -v -sweep=all
+//
+// The value of -sweep is required by the SDK but not used, the sweepers clean up everything the
+// configured credentials can see. They only delete the objects named exactly like the acceptance tests name them.
+// Each sweeper runs after the sweepers of the objects which keep its objects from being deleted.
+// Arrays, Availability Zones and Regions are never swept: the tests share the pre-existing ones.
+var testSweepers = []*resource.Sweeper{
+	{Name: "fusion_volume", F: sweepVolumes},
+	{Name: "fusion_snapshot", F: sweepSnapshots, Dependencies: []string{"fusion_volume"}},
+	{Name: "fusion_placement_group", F: sweepPlacementGroups, Dependencies: []string{"fusion_volume", "fusion_snapshot"}},
+	{Name: "fusion_tenant_space", F: sweepTenantSpaces, Dependencies: []string{"fusion_placement_group"}},
+	{Name: "fusion_role_assignment", F: sweepRoleAssignments},
+	{Name: "fusion_tenant", F: sweepTenants, Dependencies: []string{"fusion_tenant_space", "fusion_role_assignment"}},
+	{Name: "fusion_api_client", F: sweepApiClients, Dependencies: []string{"fusion_role_assignment"}},
+	{Name: "fusion_host_access_policy", F: sweepHostAccessPolicies, Dependencies: []string{"fusion_volume"}},
+	{Name: "fusion_protection_policy", F: sweepProtectionPolicies, Dependencies: []string{"fusion_volume", "fusion_placement_group"}},
+	{Name: "fusion_storage_class", F: sweepStorageClasses, Dependencies: []string{"fusion_volume"}},
+	{Name: "fusion_storage_service", F: sweepStorageServices, Dependencies: []string{"fusion_storage_class", "fusion_placement_group"}},
+	{Name: "fusion_storage_endpoint", F: sweepStorageEndpoints},
+	{Name: "fusion_network_interface_group", F: sweepNetworkInterfaceGroups, Dependencies: []string{"fusion_storage_endpoint"}},
+}
+
+func init() {
+	for _, sweeper := range testSweepers {
+		resource.AddTestSweepers(sweeper.Name, sweeper)
+	}
+}
+
+// The prefixes the acceptance tests pass to acctest.RandomWithPrefix, which appends a dash and a random
+// number. Display names are included, API clients are only told apart by theirs.
+// TestSweepNamePrefixes keeps this list in sync with the tests.
+var sweepNamePrefixes = []string{
+	"ac-display-name", "ac-display-name2", "ac_test", "ac_test2", "api-display-name", "api_test", "array_test_az",
+	"array_test_display_name", "array_test_display_name1", "array_test_display_name2", "array_test_ds",
+	"array_test_fs_array", "array_test_fs_array1", "array_test_fs_array2", "array_test_region",
+	"array_test_tf_array", "array_test_tf_array1", "array_test_tf_array2", "array_test_tf_array3", "az",
+	"az-display-name", "az-name", "az-test", "az_ds_test", "az_test", "az_test2", "az_test_region", "display-name",
+	"fake-profile", "fusion_protection_policy_test", "host-access-policy-display-name", "host0-attachTest",
+	"host0-volTest", "host1-attachTest", "host1-volTest", "host2-volTest", "host_access_policy",
+	"host_access_policy_ds_test", "hw_ds_test", "network_interface_group_ds_test", "network_interface_group_test",
+	"ni_test_display_name", "ni_test_ds", "ni_test_tf_ni", "nig-display-name", "nig-name", "nig-name-1",
+	"nig-name-2", "nig_se_test", "ops-ds-test-tenant", "perf-ds-test-tenant", "perf-ds-test-ts", "pg",
+	"pg-attachTest", "pg-ds-test-name", "pg-test-display-name", "pg-test-name", "pg-test-ss", "pg-test-tenant",
+	"pg-test-ts", "pg0-volTest", "pg1-volTest", "pg_ds_test", "placement-rec-test-ss", "placement-rec-test-tenant",
+	"placement-rec-test-ts", "placement_rec_ds_test", "pp-display-name", "pp-name", "pp-volTest", "pp0-volTest",
+	"pp1-volTest", "protection_policy_ds_test", "protection_policy_name-display-name", "protection_policy_name_test",
+	"protection_policy_test", "ra_tenant", "ra_ts", "region", "region-display-name", "region-display-name2",
+	"region-name", "region_ds_test", "region_test", "region_test2", "role_assignment", "role_ds_test",
+	"sc-attachTest", "sc-volTest", "sc0-volTest", "sc1-volTest", "se-test-az", "se-test-display-name",
+	"se-test-name", "se-test-region", "search-ds-test-tenant", "snap", "snap-pg", "snap-vol", "snapshot",
+	"snapshot-data-source", "ss-attachTest", "ss0-volTest", "ss1-volTest", "storage-class-display-name",
+	"storage-class-name", "storage-service-display-name", "storage-service-display-name2", "storage-service-name",
+	"storage_class-display-name", "storage_class-snapTest", "storage_class_ds_test", "storage_class_test",
+	"storage_class_test2", "storage_endpoint-display-name", "storage_endpoint_ds_test", "storage_endpoint_test",
+	"storage_service_ds_test", "storage_service_test", "storage_service_test2", "storage_sevice_test", "tenant",
+	"tenant-attachTest", "tenant-display-name", "tenant-display-name-1", "tenant-display-name-2",
+	"tenant-space-display-name", "tenant-space-display-name2", "tenant-space-name", "tenant-test", "tenant-volTest",
+	"tenant_ds_test", "tenant_space_ds_test", "tenant_space_test", "tenant_space_test2", "tenant_test",
+	"tenant_test_1", "tenant_test_2", "test-protection-policy", "test_hap", "test_nig", "test_region", "test_sc",
+	"test_se", "test_ss", "test_tenant", "test_tenant_1", "test_tenant_2", "test_tenant_space", "test_ts",
+	"test_vol", "test_volume", "ts-attachTest", "ts-volTest", "ts_test_tenant", "users_ds", "vol-attachTest",
+	"volume", "volume-snapshot-data-source", "volume_test_display_name", "volume_test_ds", "volume_test_fs_array",
+	"volume_test_protection_group", "volume_test_storage_class", "volume_test_storage_service", "volume_test_tenant",
+	"volume_test_tenant_space", "volume_test_tf_array",
+}
+
+// acctest.RandInt numbers are random 63 bit integers, shorter ones are too unlikely to bother
+var sweepName = regexp.MustCompile(`^(.+)-[0-9]{15,}$`)
+
+func isSweepable(name string) bool {
+	match := sweepName.FindStringSubmatch(name)
+	if match == nil {
+		return false
+	}
+	for _, prefix := range sweepNamePrefixes {
+		if match[1] == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// Configures a client the way the provider does, from the environment variables or the Fusion config file
+func sweepClient() (*Client, error) {
+	provider := Provider()
+	if diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		return nil, fmt.Errorf("cannot configure the provider: %v", diags)
+	}
+	return clientFromMeta(provider.Meta()), nil
+}
+
+// Deletes the item when the acceptance tests named it, and waits for the deletion
+func sweepItem(ctx context.Context, client *Client, kind, name string, deleteItem func() (hmrest.Operation, error)) error {
+	if !isSweepable(name) {
+		return nil
+	}
+	log.Printf("[INFO] sweeping %s %s", kind, name)
+	op, err := deleteItem()
+	return waitOnForceDestroy(ctx, client, &op, err, kind, name)
+}
+
+func sweepVolumes(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	var volumes []hmrest.Volume
+	_, err = listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		resp, _, err := client.VolumesApi.QueryVolumes(ctx, &hmrest.VolumesApiQueryVolumesOpts{
+			Limit:  optional.NewInt32(limit),
+			Offset: optional.NewInt32(offset),
+		})
+		if err != nil {
+			return 0, 0, false, err
+		}
+		volumes = append(volumes, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing volumes: %w", err)
+	}
+
+	var errs *multierror.Error
+	for _, vol := range volumes {
+		if !isSweepable(vol.Name) {
+			continue
+		}
+		log.Printf("[INFO] sweeping volume %s", vol.SelfLink)
+		op, err := eradicateVolume(ctx, client, vol.Tenant.Name, vol.TenantSpace.Name, vol)
+		errs = multierror.Append(errs, waitOnForceDestroy(ctx, client, op, err, "volume", vol.Name))
+	}
+	return errs.ErrorOrNil()
+}
+
+func sweepSnapshots(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	snapshots := &hmrest.SnapshotList{}
+	_, err = listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		resp, _, err := client.SnapshotsApi.QuerySnapshots(ctx, &hmrest.SnapshotsApiQuerySnapshotsOpts{
+			Limit:  optional.NewInt32(limit),
+			Offset: optional.NewInt32(offset),
+		})
+		if err != nil {
+			return 0, 0, false, err
+		}
+		for _, snapshot := range resp.Items {
+			if isSweepable(snapshot.Name) {
+				log.Printf("[INFO] sweeping snapshot %s", snapshot.SelfLink)
+				snapshots.Items = append(snapshots.Items, snapshot)
+			}
+		}
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing snapshots: %w", err)
+	}
+	return deleteSnapshots(ctx, snapshots, client)
+}
+
+func sweepPlacementGroups(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	var placementGroups []hmrest.PlacementGroup
+	_, err = listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		resp, _, err := client.PlacementGroupsApi.QueryPlacementGroups(ctx, &hmrest.PlacementGroupsApiQueryPlacementGroupsOpts{
+			Limit:  optional.NewInt32(limit),
+			Offset: optional.NewInt32(offset),
+		})
+		if err != nil {
+			return 0, 0, false, err
+		}
+		placementGroups = append(placementGroups, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing placement groups: %w", err)
+	}
+
+	var errs *multierror.Error
+	for _, pg := range placementGroups {
+		errs = multierror.Append(errs, sweepItem(ctx, client, "placement group", pg.Name, func() (hmrest.Operation, error) {
+			op, _, err := client.PlacementGroupsApi.DeletePlacementGroup(ctx, pg.Tenant.Name, pg.TenantSpace.Name, pg.Name, nil)
+			return op, err
+		}))
+	}
+	return errs.ErrorOrNil()
+}
+
+// Also deletes what is left in the Tenant Spaces, whatever its name
+func sweepTenantSpaces(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	var tenantSpaces []hmrest.TenantSpace
+	_, err = listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		resp, _, err := client.TenantSpacesApi.QueryTenantSpaces(ctx, &hmrest.TenantSpacesApiQueryTenantSpacesOpts{
+			Limit:  optional.NewInt32(limit),
+			Offset: optional.NewInt32(offset),
+		})
+		if err != nil {
+			return 0, 0, false, err
+		}
+		tenantSpaces = append(tenantSpaces, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing tenant spaces: %w", err)
+	}
+
+	var errs *multierror.Error
+	for _, ts := range tenantSpaces {
+		tenant := ts.Tenant.Name
+		errs = multierror.Append(errs, sweepItem(ctx, client, "tenant space", ts.Name, func() (hmrest.Operation, error) {
+			if err := emptyTenantSpace(ctx, client, tenant, ts.Name); err != nil {
+				return hmrest.Operation{}, err
+			}
+			op, _, err := client.TenantSpacesApi.DeleteTenantSpace(ctx, tenant, ts.Name, nil)
+			return op, err
+		}))
+	}
+	return errs.ErrorOrNil()
+}
+
+// Also deletes what is left in the Tenants, whatever its name
+func sweepTenants(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	var tenants []hmrest.Tenant
+	_, err = listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		resp, _, err := client.TenantsApi.ListTenants(ctx, &hmrest.TenantsApiListTenantsOpts{
+			Limit:  optional.NewInt32(limit),
+			Offset: optional.NewInt32(offset),
+		})
+		if err != nil {
+			return 0, 0, false, err
+		}
+		tenants = append(tenants, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing tenants: %w", err)
+	}
+
+	var errs *multierror.Error
+	for _, tenant := range tenants {
+		errs = multierror.Append(errs, sweepItem(ctx, client, "tenant", tenant.Name, func() (hmrest.Operation, error) {
+			if err := emptyTenant(ctx, client, tenant.Name); err != nil {
+				return hmrest.Operation{}, err
+			}
+			op, _, err := client.TenantsApi.DeleteTenant(ctx, tenant.Name, nil)
+			return op, err
+		}))
+	}
+	return errs.ErrorOrNil()
+}
+
+// Role Assignments are named by Fusion. They are swept when their scope is in a Tenant the acceptance tests
+// created, or when they are assigned to an API Client the acceptance tests created.
+func sweepRoleAssignments(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	apiClients, _, err := client.IdentityManagerApi.ListApiClients(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("listing api clients: %w", err)
+	}
+	sweptPrincipals := map[string]bool{}
+	for _, apiClient := range apiClients {
+		if isSweepable(apiClient.DisplayName) {
+			sweptPrincipals[apiClient.Id] = true
+		}
+	}
+
+	roles, _, err := client.RolesApi.ListRoles(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("listing roles: %w", err)
+	}
+
+	var errs *multierror.Error
+	for _, role := range roles {
+		roleAssignments, _, err := client.RoleAssignmentsApi.ListRoleAssignments(ctx, role.Name, nil)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("listing role assignments of role %s: %w", role.Name, err))
+			continue
+		}
+		for _, ra := range roleAssignments {
+			if !sweptPrincipals[ra.Principal] && (ra.Scope == nil || !sweepableSelfLink(ra.Scope.SelfLink)) {
+				continue
+			}
+			log.Printf("[INFO] sweeping role assignment %s of role %s", ra.Name, role.Name)
+			op, _, err := client.RoleAssignmentsApi.DeleteRoleAssignment(ctx, role.Name, ra.Name, nil)
+			errs = multierror.Append(errs, waitOnForceDestroy(ctx, client, &op, err, "role assignment", ra.Name))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// Reports whether any of the resources in the self link was named by the acceptance tests
+func sweepableSelfLink(selfLink string) bool {
+	for _, segment := range strings.Split(selfLink, "/") {
+		if isSweepable(segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// API Clients are named by Fusion, they are swept by their display name
+func sweepApiClients(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	apiClients, _, err := client.IdentityManagerApi.ListApiClients(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("listing api clients: %w", err)
+	}
+
+	var errs *multierror.Error
+	for _, apiClient := range apiClients {
+		if !isSweepable(apiClient.DisplayName) {
+			continue
+		}
+		log.Printf("[INFO] sweeping api client %s", apiClient.DisplayName)
+		if _, _, err := client.IdentityManagerApi.DeleteApiClient(ctx, apiClient.Id, nil); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("deleting api client %s: %w", apiClient.DisplayName, err))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func sweepHostAccessPolicies(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	hostAccessPolicies, _, err := client.HostAccessPoliciesApi.ListHostAccessPolicies(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("listing host access policies: %w", err)
+	}
+
+	var errs *multierror.Error
+	for _, hap := range hostAccessPolicies.Items {
+		errs = multierror.Append(errs, sweepItem(ctx, client, "host access policy", hap.Name, func() (hmrest.Operation, error) {
+			op, _, err := client.HostAccessPoliciesApi.DeleteHostAccessPolicy(ctx, hap.Name, nil)
+			return op, err
+		}))
+	}
+	return errs.ErrorOrNil()
+}
+
+func sweepProtectionPolicies(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	protectionPolicies, _, err := client.ProtectionPoliciesApi.ListProtectionPolicies(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("listing protection policies: %w", err)
+	}
+
+	var errs *multierror.Error
+	for _, pp := range protectionPolicies.Items {
+		errs = multierror.Append(errs, sweepItem(ctx, client, "protection policy", pp.Name, func() (hmrest.Operation, error) {
+			op, _, err := client.ProtectionPoliciesApi.DeleteProtectionPolicy(ctx, pp.Name, nil)
+			return op, err
+		}))
+	}
+	return errs.ErrorOrNil()
+}
+
+func sweepStorageClasses(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	storageServices, _, err := client.StorageServicesApi.ListStorageServices(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("listing storage services: %w", err)
+	}
+
+	var errs *multierror.Error
+	for _, ss := range storageServices.Items {
+		storageClasses, _, err := client.StorageClassesApi.ListStorageClasses(ctx, ss.Name, nil)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("listing storage classes of storage service %s: %w", ss.Name, err))
+			continue
+		}
+		for _, sc := range storageClasses.Items {
+			errs = multierror.Append(errs, sweepItem(ctx, client, "storage class", sc.Name, func() (hmrest.Operation, error) {
+				op, _, err := client.StorageClassesApi.DeleteStorageClass(ctx, ss.Name, sc.Name, nil)
+				return op, err
+			}))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func sweepStorageServices(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	storageServices, _, err := client.StorageServicesApi.ListStorageServices(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("listing storage services: %w", err)
+	}
+
+	var errs *multierror.Error
+	for _, ss := range storageServices.Items {
+		errs = multierror.Append(errs, sweepItem(ctx, client, "storage service", ss.Name, func() (hmrest.Operation, error) {
+			op, _, err := client.StorageServicesApi.DeleteStorageService(ctx, ss.Name, nil)
+			return op, err
+		}))
+	}
+	return errs.ErrorOrNil()
+}
+
+// Calls the function on every Availability Zone of every Region, and returns all the failures
+func sweepAvailabilityZoneItems(ctx context.Context, client *Client, sweep func(region, availabilityZone string) error) error {
+	var regions []hmrest.Region
+	_, err := listOptions{}.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		resp, _, err := client.RegionsApi.ListRegions(ctx, &hmrest.RegionsApiListRegionsOpts{
+			Limit:  optional.NewInt32(limit),
+			Offset: optional.NewInt32(offset),
+		})
+		if err != nil {
+			return 0, 0, false, err
+		}
+		regions = append(regions, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing regions: %w", err)
+	}
+
+	var errs *multierror.Error
+	for _, region := range regions {
+		availabilityZones, _, err := client.AvailabilityZonesApi.ListAvailabilityZones(ctx, region.Name, nil)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("listing availability zones of region %s: %w", region.Name, err))
+			continue
+		}
+		for _, az := range availabilityZones.Items {
+			errs = multierror.Append(errs, sweep(region.Name, az.Name))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func sweepStorageEndpoints(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	return sweepAvailabilityZoneItems(ctx, client, func(region, availabilityZone string) error {
+		storageEndpoints, _, err := client.StorageEndpointsApi.ListStorageEndpoints(ctx, region, availabilityZone, nil)
+		if err != nil {
+			return fmt.Errorf("listing storage endpoints of availability zone %s: %w", availabilityZone, err)
+		}
+		var errs *multierror.Error
+		for _, se := range storageEndpoints.Items {
+			errs = multierror.Append(errs, sweepItem(ctx, client, "storage endpoint", se.Name, func() (hmrest.Operation, error) {
+				op, _, err := client.StorageEndpointsApi.DeleteStorageEndpoint(ctx, region, availabilityZone, se.Name, nil)
+				return op, err
+			}))
+		}
+		return errs.ErrorOrNil()
+	})
+}
+
+func sweepNetworkInterfaceGroups(_ string) error {
+	ctx := context.Background()
+	client, err := sweepClient()
+	if err != nil {
+		return err
+	}
+
+	return sweepAvailabilityZoneItems(ctx, client, func(region, availabilityZone string) error {
+		networkInterfaceGroups, _, err := client.NetworkInterfaceGroupsApi.ListNetworkInterfaceGroups(ctx, region, availabilityZone, nil)
+		if err != nil {
+			return fmt.Errorf("listing network interface groups of availability zone %s: %w", availabilityZone, err)
+		}
+		var errs *multierror.Error
+		for _, nig := range networkInterfaceGroups.Items {
+			errs = multierror.Append(errs, sweepItem(ctx, client, "network interface group", nig.Name, func() (hmrest.Operation, error) {
+				op, _, err := client.NetworkInterfaceGroupsApi.DeleteNetworkInterfaceGroup(ctx, region, availabilityZone, nig.Name, nil)
+				return op, err
+			}))
+		}
+		return errs.ErrorOrNil()
+	})
+}
+
+func TestSweepers_fakeApi(t *testing.T) {
+	server := fakefusion.NewServer()
+	t.Cleanup(server.Close)
+	t.Setenv(hostVar, server.URL)
+	t.Setenv(accessTokenVar, "fake-access-token")
+	preexisting := server.SelfLinks()
+	const leaked = "-5577006791947779410"
+
+	for _, seed := range []struct {
+		collection string
+		body       map[string]interface{}
+	}{
+		{"/host-access-policies", map[string]interface{}{"name": "prod-host", "iqn": "iqn.2023-01.com.example:prod", "personality": "linux"}},
+		{"/host-access-policies", map[string]interface{}{"name": "test_hap" + leaked, "iqn": "iqn.2023-01.com.example:test", "personality": "linux"}},
+		{"/storage-services", map[string]interface{}{"name": "prod-ss", "hardware_types": []interface{}{"flash-array-x"}}},
+		{"/storage-services/prod-ss/storage-classes", map[string]interface{}{"name": "prod-sc", "size_limit": 1 << 40}},
+		{"/storage-services", map[string]interface{}{"name": "storage_service_test" + leaked, "hardware_types": []interface{}{"flash-array-x"}}},
+		{"/storage-services/storage_service_test" + leaked + "/storage-classes", map[string]interface{}{"name": "storage_class_test" + leaked, "size_limit": 1 << 40}},
+
+		// Named by people, like the tests but for the random number
+		{"/host-access-policies", map[string]interface{}{"name": "host_access_policy-2", "iqn": "iqn.2023-01.com.example:hap2", "personality": "linux"}},
+		{"/tenants", map[string]interface{}{"name": "tenant-01"}},
+
+		// A leaked Volume in a Tenant Space which stays
+		{"/tenants", map[string]interface{}{"name": "prod"}},
+		{"/tenants/prod/tenant-spaces", map[string]interface{}{"name": "prod-ts"}},
+		{"/tenants/prod/tenant-spaces/prod-ts/placement-groups", map[string]interface{}{
+			"name": "prod-pg", "region": fakefusion.DefaultRegion, "availability_zone": fakefusion.DefaultAvailabilityZone, "storage_service": "prod-ss",
+		}},
+		{"/tenants/prod/tenant-spaces/prod-ts/volumes", map[string]interface{}{
+			"name": "prod-vol", "size": 1048576, "storage_class": "prod-sc", "placement_group": "prod-pg", "host_access_policies": []interface{}{"prod-host"},
+		}},
+		{"/tenants/prod/tenant-spaces/prod-ts/volumes", map[string]interface{}{
+			"name": "test_vol" + leaked, "size": 1048576, "storage_class": "prod-sc", "placement_group": "prod-pg", "host_access_policies": []interface{}{"test_hap" + leaked},
+		}},
+
+		// A leaked Tenant, with contents named by the test or not
+		{"/tenants", map[string]interface{}{"name": "tenant_test" + leaked}},
+		{"/tenants/tenant_test" + leaked + "/tenant-spaces", map[string]interface{}{"name": "ts"}},
+		{"/tenants/tenant_test" + leaked + "/tenant-spaces/ts/placement-groups", map[string]interface{}{
+			"name": "pg", "region": fakefusion.DefaultRegion, "availability_zone": fakefusion.DefaultAvailabilityZone, "storage_service": "storage_service_test" + leaked,
+		}},
+		{"/tenants/tenant_test" + leaked + "/tenant-spaces/ts/volumes", map[string]interface{}{
+			"name": "vol", "size": 1048576, "storage_class": "storage_class_test" + leaked, "placement_group": "pg",
+		}},
+		{"/tenants/tenant_test" + leaked + "/tenant-spaces/ts/snapshots", map[string]interface{}{"name": "snapshot" + leaked, "volumes": []interface{}{"vol"}}},
+	} {
+		if err := server.Seed(seed.collection, seed.body); err != nil {
+			t.Fatalf("cannot seed %s: %s", seed.collection, err)
+		}
+	}
+
+	// In dependency order, leaving out the kinds the fake does not serve
+	for _, sweep := range []resource.SweeperFunc{
+		sweepVolumes, sweepSnapshots, sweepPlacementGroups, sweepTenantSpaces, sweepTenants,
+		sweepHostAccessPolicies, sweepProtectionPolicies, sweepStorageClasses, sweepStorageServices,
+	} {
+		if err := sweep("all"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	expected := append(preexisting,
+		"/host-access-policies/host_access_policy-2",
+		"/host-access-policies/prod-host",
+		"/storage-services/prod-ss",
+		"/storage-services/prod-ss/storage-classes/prod-sc",
+		"/tenants/prod",
+		"/tenants/tenant-01",
+		"/tenants/prod/tenant-spaces/prod-ts",
+		"/tenants/prod/tenant-spaces/prod-ts/placement-groups/prod-pg",
+		"/tenants/prod/tenant-spaces/prod-ts/volumes/prod-vol",
+	)
+	sort.Strings(expected)
+	if selfLinks := server.SelfLinks(); !reflect.DeepEqual(selfLinks, expected) {
+		t.Errorf("expected %v to be left, got %v", expected, selfLinks)
+	}
+}
+
+func TestIsSweepable(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"tenant_test-5577006791947779410":      true,
+		"host0-volTest-8674665223082153551":    true,
+		"ac-display-name-6129484611666145821":  true,
+		"tenant_test":                          false,
+		"tenant-01":                            false,
+		"array-1":                              false,
+		"vol-2":                                false,
+		"pg-3":                                 false,
+		"production-42":                        false,
+		"pure-us-west":                         false,
+		"prod_tenant_test-5577006791947779410": false,
+		"tenant_test_prod-5577006791947779410": false,
+	} {
+		if isSweepable(name) != expected {
+			t.Errorf("expected isSweepable(%q) to be %t", name, expected)
+		}
+	}
+}
+
+// Every prefix the tests name objects with must be swept
+func TestSweepNamePrefixes(t *testing.T) {
+	files, err := filepath.Glob("*_test.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	prefixPattern := regexp.MustCompile(`RandomWithPrefix\("([^"]*)"\)`)
+	for _, file := range files {
+		source, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for _, match := range prefixPattern.FindAllStringSubmatch(string(source), -1) {
+			if name := match[1] + "-5577006791947779410"; !isSweepable(name) {
+				t.Errorf("%s names objects with prefix %q, add it to sweepNamePrefixes", file, match[1])
+			}
+		}
+	}
+}
@@ -48,6 +48,8 @@ func resourceTenant() *schema.Resource {
 		` an Organization to group Tenant Spaces. It enables departments within a company to operate autonomously.` +
 		` It is created by an AZ Admin.`
 	tenantResourceFunctions.Resource.Schema = schemaTenant()
+	tenantResourceFunctions.Resource.Schema[optionForceDestroy] = schemaForceDestroy("Tenant",
+		"Tenant Spaces, with their Volumes, Snapshots and Placement Groups,")
 
 	return tenantResourceFunctions.Resource
 }
@@ -61,7 +63,7 @@ func (p *tenantProvider) PrepareCreate(ctx context.Context, d *schema.ResourceDa
 		DisplayName: displayName,
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.TenantsApi.CreateTenant(ctx, *body.(*hmrest.TenantPost), nil)
 		return &op, err
 	}
@@ -69,7 +71,7 @@ func (p *tenantProvider) PrepareCreate(ctx context.Context, d *schema.ResourceDa
 	return fn, &body, nil
 }
 
-func (p *tenantProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (p *tenantProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	t, _, err := client.TenantsApi.GetTenantById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -78,32 +80,42 @@ func (p *tenantProvider) ReadResource(ctx context.Context, client *hmrest.APICli
 	return p.loadTenant(t, d)
 }
 
-func (p *tenantProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (p *tenantProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	name := rdString(ctx, d, optionName)
+	forceDestroy := d.Get(optionForceDestroy).(bool)
+
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
+		if forceDestroy {
+			tflog.Info(ctx, "Deleting the Tenant Spaces of the Tenant in order to delete it", optionTenant, name)
+			if err := emptyTenant(ctx, client, name); err != nil {
+				return nil, err
+			}
+		}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.TenantsApi.DeleteTenant(ctx, name, nil)
 		return &op, err
 	}
 	return fn, nil
 }
 
-func (p *tenantProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+func (p *tenantProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
 	var patches []ResourcePatch
 	name := rdString(ctx, d, optionName)
 
-	if d.HasChangeExcept(optionDisplayName) {
+	if d.HasChangesExcept(optionDisplayName, optionForceDestroy) {
 		d.Partial(true)
 		return nil, nil, fmt.Errorf("attempting to update an immutable field")
 	}
 
-	displayName := rdStringDefault(ctx, d, optionDisplayName, name)
-	tflog.Info(ctx, "Updating", optionDisplayName, displayName)
-	patches = append(patches, &hmrest.TenantPatch{
-		DisplayName: &hmrest.NullableString{Value: displayName},
-	})
+	if d.HasChange(optionDisplayName) {
+		displayName := rdStringDefault(ctx, d, optionDisplayName, name)
+		tflog.Info(ctx, "Updating", optionDisplayName, displayName)
+		patches = append(patches, &hmrest.TenantPatch{
+			DisplayName: &hmrest.NullableString{Value: displayName},
+		})
+	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.TenantsApi.UpdateTenant(ctx, *body.(*hmrest.TenantPatch), name, nil)
 		return &op, err
 	}
@@ -111,7 +123,7 @@ func (p *tenantProvider) PrepareUpdate(ctx context.Context, client *hmrest.APICl
 	return fn, patches, nil
 }
 
-func (p *tenantProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *tenantProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{resourceGroupNameTenant}
 	// The ID is user provided value - we expect self link
 	selfLinkFieldsWithValues, err := utilities.ParseSelfLink(d.Id(), orderedRequiredGroupNames)
@@ -8,6 +8,7 @@ package fusion
 import (
 	"context"
 
+	"github.com/antihax/optional"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
@@ -23,6 +24,8 @@ func dataSourceTenant() *schema.Resource {
 	ds := &tenantDataSource{}
 
 	dsSchema := map[string]*schema.Schema{
+		optionName:        schemaListMatch("Only the Tenant with this name."),
+		optionDisplayName: schemaListMatch("Only the Tenants with this display name."),
 		optionItems: {
 			Type:     schema.TypeList,
 			Computed: true,
@@ -32,27 +35,51 @@ func dataSourceTenant() *schema.Resource {
 			Description: "List of matching Tenants.",
 		},
 	}
+	withListOptions(dsSchema, "Tenants")
 
 	tenantDataSourceFunctions := NewBaseDataSourceFunctions(resourceKindTenant, ds, dsSchema)
 	return tenantDataSourceFunctions.Resource
 }
 
-func (ds *tenantDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
-	resp, _, err := client.TenantsApi.ListTenants(ctx, nil)
+func (ds *tenantDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	listOpts := rdListOptions(d)
+
+	opts := hmrest.TenantsApiListTenantsOpts{
+		Filter:      optionalString(listOpts.filter),
+		Sort:        optionalString(listOpts.sort),
+		Name:        optionalString(rdString(ctx, d, optionName)),
+		DisplayName: optionalString(rdString(ctx, d, optionDisplayName)),
+	}
+
+	var tenants []hmrest.Tenant
+	moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		opts.Limit = optional.NewInt32(limit)
+		opts.Offset = optional.NewInt32(offset)
+		resp, _, err := client.TenantsApi.ListTenants(ctx, &opts)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		tenants = append(tenants, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	tenantList := make([]map[string]interface{}, 0, resp.Count)
+	tenantList := make([]map[string]interface{}, 0, len(tenants))
 
-	for _, tenant := range resp.Items {
+	for _, tenant := range tenants {
 		tenantList = append(tenantList, map[string]interface{}{
 			optionName:        tenant.Name,
 			optionDisplayName: tenant.DisplayName,
 		})
 	}
 
-	if err := d.Set(optionItems, tenantList); err != nil {
+	err = getFirstError(
+		d.Set(optionItems, tenantList),
+		d.Set(optionMoreItemsRemaining, moreItemsRemaining),
+	)
+	if err != nil {
 		return err
 	}
 
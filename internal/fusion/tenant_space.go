@@ -54,6 +54,8 @@ func resourceTenantSpace() *schema.Resource {
 	tenantSpaceResourceFunctions.Resource.Description = `A Tenant Space (e.g. "Wiki") contains resources ` +
 		`(Volumes, Volume Snapshots, etc.) and exists inside a Tenant.`
 	tenantSpaceResourceFunctions.Resource.Schema = schemaTenantSpace()
+	tenantSpaceResourceFunctions.Resource.Schema[optionDeletionProtection] = schemaDeletionProtection("Tenant Space")
+	tenantSpaceResourceFunctions.Resource.Schema[optionForceDestroy] = schemaForceDestroy("Tenant Space", "Volumes, Snapshots and Placement Groups")
 
 	return tenantSpaceResourceFunctions.Resource
 }
@@ -69,14 +71,14 @@ func (p *tenantSpaceProvider) PrepareCreate(ctx context.Context, d *schema.Resou
 	}
 
 	// REVIEW: Should we return an interface instead? What does that look like? The closure lets us use variables above.
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.TenantSpacesApi.CreateTenantSpace(ctx, *body.(*hmrest.TenantSpacePost), tenant, nil)
 		return &op, err
 	}
 	return fn, &body, nil
 }
 
-func (p *tenantSpaceProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (p *tenantSpaceProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	ts, _, err := client.TenantSpacesApi.GetTenantSpaceById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -85,19 +87,27 @@ func (p *tenantSpaceProvider) ReadResource(ctx context.Context, client *hmrest.A
 	return p.loadTenantSpace(ts, d)
 }
 
-func (p *tenantSpaceProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (p *tenantSpaceProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	name := rdString(ctx, d, optionName)
 	tenant := rdString(ctx, d, optionTenant)
+	forceDestroy := d.Get(optionForceDestroy).(bool)
+
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
+		if forceDestroy {
+			tflog.Info(ctx, "Deleting the contents of the Tenant Space in order to delete it", optionTenant, tenant, optionTenantSpace, name)
+			if err := emptyTenantSpace(ctx, client, tenant, name); err != nil {
+				return nil, err
+			}
+		}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.TenantSpacesApi.DeleteTenantSpace(ctx, tenant, name, nil)
 		return &op, err
 	}
 	return fn, nil
 }
 
-func (p *tenantSpaceProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
-	if d.HasChangeExcept(optionDisplayName) {
+func (p *tenantSpaceProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+	if d.HasChangesExcept(optionDisplayName, optionDeletionProtection, optionForceDestroy) {
 		d.Partial(true)
 		return nil, nil, fmt.Errorf("attempting to update an immutable field")
 	}
@@ -106,14 +116,15 @@ func (p *tenantSpaceProvider) PrepareUpdate(ctx context.Context, client *hmrest.
 	displayName := rdStringDefault(ctx, d, optionDisplayName, name)
 	tenant := rdString(ctx, d, optionTenant)
 
-	tflog.Info(ctx, "Updating", optionDisplayName, displayName)
-	patches := []ResourcePatch{
-		&hmrest.TenantSpacePatch{
+	var patches []ResourcePatch
+	if d.HasChange(optionDisplayName) {
+		tflog.Info(ctx, "Updating", optionDisplayName, displayName)
+		patches = append(patches, &hmrest.TenantSpacePatch{
 			DisplayName: &hmrest.NullableString{Value: displayName},
-		},
+		})
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.TenantSpacesApi.UpdateTenantSpace(ctx, *body.(*hmrest.TenantSpacePatch), tenant, name, nil)
 		return &op, err
 	}
@@ -121,7 +132,7 @@ func (p *tenantSpaceProvider) PrepareUpdate(ctx context.Context, client *hmrest.
 	return fn, patches, nil
 }
 
-func (p *tenantSpaceProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (p *tenantSpaceProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	var orderedRequiredGroupNames = []string{
 		resourceGroupNameTenant,
 		resourceGroupNameTenantSpace,
@@ -8,6 +8,7 @@ package fusion
 import (
 	"context"
 
+	"github.com/antihax/optional"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -29,6 +30,8 @@ func dataSourceTenantSpace() *schema.Resource {
 			ValidateFunc: validation.StringIsNotEmpty,
 			Description:  "The name of the Tenant.",
 		},
+		optionName:        schemaListMatch("Only the Tenant Space with this name."),
+		optionDisplayName: schemaListMatch("Only the Tenant Spaces with this display name."),
 		optionItems: {
 			Type:     schema.TypeList,
 			Computed: true,
@@ -38,21 +41,41 @@ func dataSourceTenantSpace() *schema.Resource {
 			Description: "List of matching Tenant Spaces.",
 		},
 	}
+	withListOptions(dsSchema, "Tenant Spaces")
 
 	tenantSpaceDataSourceFunctions := NewBaseDataSourceFunctions(resourceKindTenantSpace, ds, dsSchema)
 
 	return tenantSpaceDataSourceFunctions.Resource
 }
 
-func (ds *tenantSpaceDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
-	resp, _, err := client.TenantSpacesApi.ListTenantSpaces(ctx, rdString(ctx, d, optionTenant), nil)
+func (ds *tenantSpaceDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	listOpts := rdListOptions(d)
+
+	opts := hmrest.TenantSpacesApiListTenantSpacesOpts{
+		Filter:      optionalString(listOpts.filter),
+		Sort:        optionalString(listOpts.sort),
+		Name:        optionalString(rdString(ctx, d, optionName)),
+		DisplayName: optionalString(rdString(ctx, d, optionDisplayName)),
+	}
+
+	var tenantSpaces []hmrest.TenantSpace
+	moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		opts.Limit = optional.NewInt32(limit)
+		opts.Offset = optional.NewInt32(offset)
+		resp, _, err := client.TenantSpacesApi.ListTenantSpaces(ctx, rdString(ctx, d, optionTenant), &opts)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		tenantSpaces = append(tenantSpaces, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	tenantSpacesList := make([]map[string]interface{}, 0, resp.Count)
+	tenantSpacesList := make([]map[string]interface{}, 0, len(tenantSpaces))
 
-	for _, ts := range resp.Items {
+	for _, ts := range tenantSpaces {
 		tenantSpacesList = append(tenantSpacesList, map[string]interface{}{
 			optionName:        ts.Name,
 			optionDisplayName: ts.DisplayName,
@@ -60,7 +83,11 @@ func (ds *tenantSpaceDataSource) ReadDataSource(ctx context.Context, client *hmr
 		})
 	}
 
-	if err := d.Set(optionItems, tenantSpacesList); err != nil {
+	err = getFirstError(
+		d.Set(optionItems, tenantSpacesList),
+		d.Set(optionMoreItemsRemaining, moreItemsRemaining),
+	)
+	if err != nil {
 		return err
 	}
 
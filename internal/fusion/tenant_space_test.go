@@ -17,8 +17,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-
-	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
 // Creates and destroys
@@ -238,10 +236,11 @@ func TestAccTenantSpace_import(t *testing.T) {
 				),
 			},
 			{
-				ImportState:       true,
-				ResourceName:      fmt.Sprintf("fusion_tenant_space.%s", rNameConfig),
-				ImportStateId:     fmt.Sprintf("/tenants/%[1]s/tenant-spaces/%[2]s", tenant, tenantSpaceName),
-				ImportStateVerify: true,
+				ImportState:             true,
+				ResourceName:            fmt.Sprintf("fusion_tenant_space.%s", rNameConfig),
+				ImportStateId:           fmt.Sprintf("/tenants/%[1]s/tenant-spaces/%[2]s", tenant, tenantSpaceName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{optionDeletionProtection, optionForceDestroy},
 			},
 			{
 				ImportState:   true,
@@ -259,6 +258,44 @@ func TestAccTenantSpace_import(t *testing.T) {
 	})
 }
 
+// Refuses to delete a protected Tenant Space until the protection is turned off
+func TestAccTenantSpace_deletionProtection(t *testing.T) {
+	utilities.CheckTestSkip(t)
+
+	rNameConfig := acctest.RandomWithPrefix("tenant_space_test")
+	rName := "fusion_tenant_space." + rNameConfig
+	tenantSpaceName := acctest.RandomWithPrefix("test_ts")
+
+	tenant := acctest.RandomWithPrefix("ts_test_tenant")
+	commonConfig := testTenantConfig(tenant, tenant, tenant)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testCheckTenantSpaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: commonConfig + testTenantSpaceConfigDeletionProtection(rNameConfig, tenantSpaceName, tenant, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rName, "deletion_protection", "true"),
+					testTenantSpaceExists(rName),
+				),
+			},
+			{
+				Config:      commonConfig,
+				ExpectError: regexp.MustCompile("deletion_protection is enabled"),
+			},
+			{
+				Config: commonConfig + testTenantSpaceConfigDeletionProtection(rNameConfig, tenantSpaceName, tenant, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rName, "deletion_protection", "false"),
+					testTenantSpaceExists(rName),
+				),
+			},
+		},
+	})
+}
+
 func testTenantSpaceExists(rName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		tfTenantSpace, ok := s.RootModule().Resources[rName]
@@ -270,7 +307,7 @@ func testTenantSpaceExists(rName string) resource.TestCheckFunc {
 		}
 		attrs := tfTenantSpace.Primary.Attributes
 
-		goclientTenantSpace, _, err := testAccProvider.Meta().(*hmrest.APIClient).TenantSpacesApi.GetTenantSpaceById(context.Background(), attrs["id"], nil)
+		goclientTenantSpace, _, err := testAccProvider.Meta().(*Client).TenantSpacesApi.GetTenantSpaceById(context.Background(), attrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client retutrned error while searching for %s by id: %s. Error: %s", attrs["name"], attrs["id"], err)
 		}
@@ -285,7 +322,7 @@ func testTenantSpaceExists(rName string) resource.TestCheckFunc {
 
 func testCheckTenantSpaceDestroy(s *terraform.State) error {
 
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_tenant_space" {
@@ -337,3 +374,13 @@ func testTenantSpaceConfigNoDisplayNameWithRefs(rName string, tenantSpaceName st
 	}
 	`, rName, tenantSpaceName, tenantName)
 }
+
+func testTenantSpaceConfigDeletionProtection(rName string, tenantSpaceName string, tenantName string, deletionProtection bool) string {
+	return fmt.Sprintf(`
+	resource "fusion_tenant_space" "%[1]s" {
+		name				= "%[2]s"
+		tenant				= fusion_tenant.%[3]s.name
+		deletion_protection	= %[4]t
+	}
+	`, rName, tenantSpaceName, tenantName, deletionProtection)
+}
@@ -190,10 +190,11 @@ func TestAccTenant_import(t *testing.T) {
 				),
 			},
 			{
-				ImportState:       true,
-				ResourceName:      fmt.Sprintf("fusion_tenant.%s", rNameConfig),
-				ImportStateId:     fmt.Sprintf("/tenants/%s", tenantName),
-				ImportStateVerify: true,
+				ImportState:             true,
+				ResourceName:            fmt.Sprintf("fusion_tenant.%s", rNameConfig),
+				ImportStateId:           fmt.Sprintf("/tenants/%s", tenantName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{optionForceDestroy},
 			},
 			{
 				ImportState:   true,
@@ -211,6 +212,44 @@ func TestAccTenant_import(t *testing.T) {
 	})
 }
 
+// Deletes a Tenant still holding a Tenant Space Terraform does not manage
+func TestAccTenant_forceDestroy(t *testing.T) {
+	utilities.CheckTestSkip(t)
+
+	rNameConfig := acctest.RandomWithPrefix("tenant_test")
+	rName := "fusion_tenant." + rNameConfig
+	tenantName := acctest.RandomWithPrefix("test_tenant")
+	tenantSpaceName := acctest.RandomWithPrefix("test_ts")
+
+	ctx := setupTestCtx(t)
+	hmClient := testAccPreCheckWithReturningClient(ctx, t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testCheckTenantDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "fusion_tenant" "%[1]s" {
+					name          = "%[2]s"
+					force_destroy = true
+				}
+				`, rNameConfig, tenantName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rName, "force_destroy", "true"),
+					testTenantExists(rName),
+					func(s *terraform.State) error {
+						testVolumeDoOperation(t, ctx, hmClient, "tenant-space-create")(
+							hmClient.TenantSpacesApi.CreateTenantSpace(ctx, hmrest.TenantSpacePost{Name: tenantSpaceName}, tenantName, nil),
+						)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testTenantExists(rName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		tfTenant, ok := s.RootModule().Resources[rName]
@@ -222,7 +261,7 @@ func testTenantExists(rName string) resource.TestCheckFunc {
 		}
 		attrs := tfTenant.Primary.Attributes
 
-		goclientTenant, _, err := testAccProvider.Meta().(*hmrest.APIClient).TenantsApi.GetTenantById(context.Background(), attrs["id"], nil)
+		goclientTenant, _, err := testAccProvider.Meta().(*Client).TenantsApi.GetTenantById(context.Background(), attrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client retutrned error while searching for %s by id: %s. Error: %s", attrs["name"], attrs["id"], err)
 		}
@@ -235,7 +274,7 @@ func testTenantExists(rName string) resource.TestCheckFunc {
 }
 
 func testCheckTenantDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*hmrest.APIClient)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "fusion_tenant" {
@@ -73,7 +73,7 @@ func dataSourceUser() *schema.Resource {
 	return userDataSourceFunctions.Resource
 }
 
-func (ds *userDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *userDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	var opts hmrest.IdentityManagerApiListUsersOpts
 
 	if email, ok := d.GetOk(optionEmail); ok {
@@ -7,6 +7,7 @@ package fusion
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -111,3 +112,16 @@ func IsValidLocalRetention(v interface{}, path cty.Path) diag.Diagnostics {
 
 	return diags
 }
+
+func IsValidDuration(v interface{}, path cty.Path) diag.Diagnostics {
+	value := v.(string)
+	if duration, err := time.ParseDuration(value); err != nil || duration < 0 {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Bad duration",
+			Detail:   "Duration must be a non-negative number with a unit suffix, e.g. 30s or 2m",
+		}}
+	}
+
+	return diag.Diagnostics{}
+}
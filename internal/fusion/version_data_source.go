@@ -0,0 +1,50 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Implements DataSource
+type versionDataSource struct{}
+
+// This is our entry point for the Version data source
+func dataSourceVersion() *schema.Resource {
+	ds := &versionDataSource{}
+
+	dsSchema := map[string]*schema.Schema{
+		optionVersion: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The API version of the Pure Fusion control plane, e.g. `1.2`.",
+		},
+	}
+
+	versionDataSourceFunctions := NewBaseDataSourceFunctions("Version", ds, dsSchema)
+	versionDataSourceFunctions.Resource.Description = "Provides the API version of the Pure Fusion control plane the provider is connected to."
+
+	return versionDataSourceFunctions.Resource
+}
+
+func (ds *versionDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	resp, _, err := client.DefaultApi.GetVersion(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set(optionVersion, resp.Version); err != nil {
+		return err
+	}
+
+	d.SetId(utilities.GetIdForDataSource())
+
+	return nil
+}
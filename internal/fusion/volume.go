@@ -9,8 +9,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -20,6 +22,9 @@ import (
 	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
+// How often wait_for_copy checks the copied Volume
+var volumeCopyPollInterval = 5 * time.Second
+
 // This is our entry point for the Volume resource. Get it movin'
 func schemaVolume() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
@@ -81,7 +86,8 @@ func schemaVolume() map[string]*schema.Schema {
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
-			Description: "The list of Host Access Policies to connect the Volume to.",
+			Description: "The list of Host Access Policies to connect the Volume to. " +
+				"To attach hosts from several configurations, use `fusion_volume_host_attachment` resources instead.",
 		},
 		optionCreatedAt: {
 			Type:        schema.TypeInt,
@@ -112,6 +118,44 @@ func schemaVolume() map[string]*schema.Schema {
 			Default:     false,
 			Description: "Eradicate the Volume when the Volume is deleted.",
 		},
+		optionWwn: {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: "The World Wide Name of the Volume the hosts see, the NAA identifier `624a9370` followed by the lowercase serial number. " +
+				"Udev names the Volume `wwn-0x<wwn>` and multipath uses `3<wwn>` as its WWID.",
+		},
+		optionArray: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the Array the Volume is currently placed on.",
+		},
+		optionDestroyed: {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "True if the Volume is destroyed and pending eradication.",
+		},
+		optionTimeRemaining: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of milliseconds left before a destroyed Volume is eradicated.",
+		},
+		optionSource: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The self link of the Volume or Volume Snapshot the Volume was copied from.",
+		},
+		optionSourceVolumeSnapshot: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The self link of the Volume Snapshot the Volume was last copied or restored from.",
+		},
+		optionWaitForCopy: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			Description: "When the Volume is created from `source_link`, wait until the Volume reports its source and its space usage " +
+				"stops changing between two checks, before connecting hosts to it and reporting it as created.",
+		},
 		optionSourceLink: {
 			Type:          schema.TypeList,
 			Optional:      true,
@@ -158,6 +202,37 @@ func schemaVolume() map[string]*schema.Schema {
 				},
 			},
 		},
+		optionRestoreFrom: {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Description: "Restores the Volume in place from a Volume Snapshot of its Tenant Space, keeping its serial number. " +
+				"The Volume is restored whenever `trigger` changes on an existing Volume, nothing is restored when the Volume is created. " +
+				"The hosts are disconnected from the Volume during the restore and connected again afterwards. " +
+				"WARNING: The restore overwrites the data of the Volume.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					optionSnapshot: {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						Description:  "The name of the Snapshot.",
+					},
+					optionVolumeSnapshot: {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						Description:  "The name of the Volume Snapshot to restore.",
+					},
+					optionTrigger: {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						Description:  "Any value, e.g. a date. Changing it restores the Volume again.",
+					},
+				},
+			},
+		},
 	}
 
 }
@@ -170,6 +245,7 @@ func resourceVolume() *schema.Resource {
 		"on the array. After a Volume has been created, establish a Host-Volume connection so that the Host can read data " +
 		"from and write data to the Volume."
 	volumeResourceFunctions.Resource.Schema = schemaVolume()
+	volumeResourceFunctions.Resource.Schema[optionDeletionProtection] = schemaDeletionProtection("Volume")
 
 	return volumeResourceFunctions.Resource
 }
@@ -200,13 +276,13 @@ func (vp *volumeProvider) PrepareCreate(ctx context.Context, d *schema.ResourceD
 		body.Size = 0
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.VolumesApi.CreateVolume(ctx, *body.(*hmrest.VolumePost), tenantName, tenantSpaceName, nil)
 		if err != nil {
 			return &op, err
 		}
 
-		succeeded, err := utilities.WaitOnOperation(ctx, &op, client)
+		succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
 		if err != nil {
 			return &op, err
 		}
@@ -220,6 +296,12 @@ func (vp *volumeProvider) PrepareCreate(ctx context.Context, d *schema.ResourceD
 
 		d.SetId(op.Result.Resource.Id)
 
+		if body.(*hmrest.VolumePost).SourceLink != "" && d.Get(optionWaitForCopy).(bool) {
+			if err := vp.waitForCopy(ctx, client, tenantName, tenantSpaceName, op.Result.Resource.Name); err != nil {
+				return &op, err
+			}
+		}
+
 		hosts := strings.Join(rdStringSet(ctx, d, optionHostAccessPolicies), ",")
 		patch := hmrest.VolumePatch{
 			HostAccessPolicies: &hmrest.NullableString{Value: hosts},
@@ -233,12 +315,12 @@ func (vp *volumeProvider) PrepareCreate(ctx context.Context, d *schema.ResourceD
 			return &op, err
 		}
 
-		succeeded, err = utilities.WaitOnOperation(ctx, &op, client)
+		succeeded, err = utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
 		if err != nil {
 			return &op, err
 		}
 		if !succeeded {
-			return &op, fmt.Errorf("operation failed Message:%s ID:%s", op.Error_.Message, op.Id)
+			return &op, utilities.NewRestErrorFromOperation(&op)
 		}
 
 		return &op, nil
@@ -247,7 +329,7 @@ func (vp *volumeProvider) PrepareCreate(ctx context.Context, d *schema.ResourceD
 	return fn, &body, nil
 }
 
-func (vp *volumeProvider) ReadResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (vp *volumeProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	vol, _, err := client.VolumesApi.GetVolumeById(ctx, d.Id(), nil)
 	if err != nil {
 		return err
@@ -261,7 +343,7 @@ func (vp *volumeProvider) ReadResource(ctx context.Context, client *hmrest.APICl
 // If a new size is provided, it must be larger than the current size.  Only
 // extending volumes is supported at this time, since truncating volumes can
 // lead to data loss.
-func (vp *volumeProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
+func (vp *volumeProvider) PrepareUpdate(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, []ResourcePatch, error) {
 	if err := utilities.CheckImmutableFields(ctx, d, optionName, optionTenant, optionTenantSpace); err != nil {
 		return nil, nil, err
 	}
@@ -298,16 +380,29 @@ func (vp *volumeProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIC
 		})
 	}
 
-	// if there is a change to placement groups, then we need to remove the hosts and then re-add them
+	// Restore only on an explicit change of the trigger, not when restore_from is removed
+	restore := d.HasChange(getRestoreFromItem(optionTrigger)) && rdString(ctx, d, getRestoreFromItem(optionTrigger)) != ""
+
+	// if there is a change to placement groups or a restore, then we need to remove the hosts and then re-add them
 	reAddHosts := false
-	if d.HasChange(optionPlacementGroup) {
+	var currentHosts []string
+	if d.HasChange(optionPlacementGroup) || restore {
 		reAddHosts = true
+		if !d.HasChange(optionHostAccessPolicies) {
+			// Re-add the hosts the Volume has, not the ones in the state: hosts attached by fusion_volume_host_attachment
+			// to a Volume ignoring changes to host_access_policies are not in the state
+			vol, _, err := client.VolumesApi.GetVolume(ctx, tenantName, tenantSpaceName, volumeName, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			currentHosts = volumeHostNames(vol)
+		}
 		tflog.Trace(ctx, "update",
 			"resource", "volume",
 			"parameter", optionHostAccessPolicies,
 			"to", "",
 			"patch_idx", len(patches),
-			"message", "temporary removal of hosts for placement_groups_name change",
+			"message", "temporary removal of hosts for placement_groups_name change or restore",
 		)
 		patches = append(patches, &hmrest.VolumePatch{
 			HostAccessPolicies: &hmrest.NullableString{Value: ""},
@@ -339,8 +434,25 @@ func (vp *volumeProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIC
 		patches = append(patches, patch)
 	}
 
+	if restore {
+		sourceVolumeSnapshotLink := fmt.Sprintf("/tenants/%s/tenant-spaces/%s/snapshots/%s/volume-snapshots/%s", tenantName, tenantSpaceName,
+			rdString(ctx, d, getRestoreFromItem(optionSnapshot)), rdString(ctx, d, getRestoreFromItem(optionVolumeSnapshot)))
+		tflog.Trace(ctx, "update",
+			"resource", "volume",
+			"parameter", optionRestoreFrom,
+			"to", sourceVolumeSnapshotLink,
+			"patch_idx", len(patches),
+		)
+		patches = append(patches, &hmrest.VolumePatch{
+			SourceVolumeSnapshotLink: &hmrest.NullableString{Value: sourceVolumeSnapshotLink},
+		})
+	}
+
 	if d.HasChange(optionHostAccessPolicies) || reAddHosts {
 		s := strings.Join(rdStringSet(ctx, d, optionHostAccessPolicies), ",")
+		if !d.HasChange(optionHostAccessPolicies) {
+			s = strings.Join(currentHosts, ",")
+		}
 
 		tflog.Trace(ctx, "update",
 			"resource", "volume",
@@ -381,7 +493,7 @@ func (vp *volumeProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIC
 		})
 	}
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
 		op, _, err := client.VolumesApi.UpdateVolume(ctx, *body.(*hmrest.VolumePatch), tenantName, tenantSpaceName, volumeName, nil)
 		return &op, err
 	}
@@ -389,65 +501,73 @@ func (vp *volumeProvider) PrepareUpdate(ctx context.Context, client *hmrest.APIC
 	return fn, patches, nil
 }
 
-func (vp *volumeProvider) PrepareDelete(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) (InvokeWriteAPI, error) {
+func (vp *volumeProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
 	volumeName := d.Get(optionName).(string)
 	tenantSpaceName := d.Get(optionTenantSpace).(string)
 	tenantName := d.Get(optionTenant).(string)
 	eradicate := d.Get(optionEradicateOnDelete).(bool)
 
-	fn := func(ctx context.Context, client *hmrest.APIClient, body RequestSpec) (*hmrest.Operation, error) {
-		tflog.Trace(ctx, "removing host assignments before deleting volume")
-		op, _, err := client.VolumesApi.UpdateVolume(ctx, hmrest.VolumePatch{
-			HostAccessPolicies: &hmrest.NullableString{Value: ""},
-		}, tenantName, tenantSpaceName, volumeName, nil)
-		utilities.TraceError(ctx, err)
-		if err != nil {
-			return &op, err
-		}
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
+		return deleteVolume(ctx, client, tenantName, tenantSpaceName, volumeName, eradicate)
+	}
+	return fn, nil
+}
 
-		succeeded, err := utilities.WaitOnOperation(ctx, &op, client)
-		if err != nil {
-			return &op, err
-		}
-		if !succeeded {
-			tflog.Error(ctx, "failed removing host assignments")
-			return &op, fmt.Errorf("failed to clear out host assignments as part of deleting volume")
-		}
-		tflog.Trace(ctx, "done removing host assignments")
+// Disconnects the hosts of the Volume and destroys it, then eradicates it if asked to.
+// Returns the last operation without waiting for it.
+func deleteVolume(
+	ctx context.Context, client *Client, tenantName, tenantSpaceName, volumeName string, eradicate bool,
+) (*hmrest.Operation, error) {
+	tflog.Trace(ctx, "removing host assignments before deleting volume")
+	op, _, err := client.VolumesApi.UpdateVolume(ctx, hmrest.VolumePatch{
+		HostAccessPolicies: &hmrest.NullableString{Value: ""},
+	}, tenantName, tenantSpaceName, volumeName, nil)
+	utilities.TraceError(ctx, err)
+	if err != nil {
+		return &op, err
+	}
 
-		tflog.Trace(ctx, "destroying volume")
-		op, _, err = client.VolumesApi.UpdateVolume(ctx, hmrest.VolumePatch{
-			Destroyed: &hmrest.NullableBoolean{Value: true},
-		}, tenantName, tenantSpaceName, volumeName, nil)
+	succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
+	if err != nil {
+		return &op, err
+	}
+	if !succeeded {
+		tflog.Error(ctx, "failed removing host assignments")
+		return &op, fmt.Errorf("failed to clear out host assignments as part of deleting volume")
+	}
+	tflog.Trace(ctx, "done removing host assignments")
 
-		// Do not eradicate the volume - return the operation for patching the volume (destroyed=true)
-		if !eradicate {
-			return &op, err
-		}
+	tflog.Trace(ctx, "destroying volume")
+	op, _, err = client.VolumesApi.UpdateVolume(ctx, hmrest.VolumePatch{
+		Destroyed: &hmrest.NullableBoolean{Value: true},
+	}, tenantName, tenantSpaceName, volumeName, nil)
 
-		utilities.TraceError(ctx, err)
-		if err != nil {
-			return &op, err
-		}
+	// Do not eradicate the volume - return the operation for patching the volume (destroyed=true)
+	if !eradicate {
+		return &op, err
+	}
 
-		// Wait for patching the volume (destroyed=true)
-		succeeded, err = utilities.WaitOnOperation(ctx, &op, client)
-		if err != nil {
-			return &op, err
-		}
-		if !succeeded {
-			tflog.Error(ctx, "failed destroying volume")
-			return &op, fmt.Errorf("failed destroying volume")
-		}
-		tflog.Trace(ctx, "done destroying volume")
+	utilities.TraceError(ctx, err)
+	if err != nil {
+		return &op, err
+	}
 
-		op, _, err = client.VolumesApi.DeleteVolume(ctx, tenantName, tenantSpaceName, volumeName, nil)
+	// Wait for patching the volume (destroyed=true)
+	succeeded, err = utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
+	if err != nil {
 		return &op, err
 	}
-	return fn, nil
+	if !succeeded {
+		tflog.Error(ctx, "failed destroying volume")
+		return &op, fmt.Errorf("failed destroying volume")
+	}
+	tflog.Trace(ctx, "done destroying volume")
+
+	op, _, err = client.VolumesApi.DeleteVolume(ctx, tenantName, tenantSpaceName, volumeName, nil)
+	return &op, err
 }
 
-func (vp *volumeProvider) ImportResource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+func (vp *volumeProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
 	orderedRequiredGroupNames := []string{
 		resourceGroupNameTenant,
 		resourceGroupNameTenantSpace,
@@ -505,12 +625,8 @@ func (vp *volumeProvider) getSourceLink(ctx context.Context, d *schema.ResourceD
 }
 
 func (vp *volumeProvider) loadVolume(volume hmrest.Volume, d *schema.ResourceData) error {
-	hostNames := []string{}
-	for _, hap := range volume.HostAccessPolicies {
-		hostNames = append(hostNames, hap.Name)
-	}
 	err := getFirstError(
-		d.Set(optionHostAccessPolicies, hostNames),
+		d.Set(optionHostAccessPolicies, volumeHostNames(volume)),
 		d.Set(optionTenant, volume.Tenant.Name),
 		d.Set(optionTenantSpace, volume.TenantSpace.Name),
 		d.Set(optionStorageClass, volume.StorageClass.Name),
@@ -519,10 +635,16 @@ func (vp *volumeProvider) loadVolume(volume hmrest.Volume, d *schema.ResourceDat
 		d.Set(optionDisplayName, volume.DisplayName),
 		d.Set(optionSize, strconv.FormatInt(volume.Size, 10)),
 		d.Set(optionSerialNumber, volume.SerialNumber),
+		d.Set(optionWwn, volumeWwn(volume.SerialNumber)),
+		d.Set(optionDestroyed, volume.Destroyed),
+		d.Set(optionTimeRemaining, volume.TimeRemaining),
 		d.Set(optionCreatedAt, volume.CreatedAt),
+		d.Set(optionArray, nil),
 		d.Set(optionProtectionPolicy, nil),
 		d.Set(optionTargetIscsiIqn, nil),
 		d.Set(optionTargetIscsiAddresses, nil),
+		d.Set(optionSource, nil),
+		d.Set(optionSourceVolumeSnapshot, nil),
 	)
 	if volume.ProtectionPolicy != nil {
 		err = getFirstError(err, d.Set(optionProtectionPolicy, volume.ProtectionPolicy.Name))
@@ -534,11 +656,71 @@ func (vp *volumeProvider) loadVolume(volume hmrest.Volume, d *schema.ResourceDat
 			d.Set(optionTargetIscsiAddresses, volume.Target.Iscsi.Addresses),
 		)
 	}
+	if volume.Array != nil {
+		err = getFirstError(err, d.Set(optionArray, volume.Array.Name))
+	}
+	if volume.Source != nil {
+		err = getFirstError(err, d.Set(optionSource, volume.Source.SelfLink))
+	}
+	if volume.SourceVolumeSnapshot != nil {
+		err = getFirstError(err, d.Set(optionSourceVolumeSnapshot, volume.SourceVolumeSnapshot.SelfLink))
+	}
 	return err
 }
 
+func volumeHostNames(volume hmrest.Volume) []string {
+	hostNames := []string{}
+	for _, hap := range volume.HostAccessPolicies {
+		hostNames = append(hostNames, hap.Name)
+	}
+	return hostNames
+}
+
+// The create operation of a copy succeeds before all the copied data lands on the Volume.
+// Fusion does not report the progress of a copy, so polls the Volume until it reports the source it was copied from,
+// and its space usage is the same in two consecutive checks. Stops when the create times out.
+func (vp *volumeProvider) waitForCopy(ctx context.Context, client *Client, tenant, tenantSpace, name string) error {
+	stopped := func(err error) error {
+		if ctx.Err() != nil {
+			return fmt.Errorf("stopped waiting for the copy to volume %s: %w", name, ctx.Err())
+		}
+		return err
+	}
+
+	var lastSpace *hmrest.Space
+	for {
+		vol, _, err := client.VolumesApi.GetVolume(ctx, tenant, tenantSpace, name, nil)
+		if err != nil {
+			return stopped(err)
+		}
+		copied := vol.Source != nil || vol.SourceVolumeSnapshot != nil
+
+		var space hmrest.Space
+		if copied {
+			space, _, err = client.VolumesApi.GetVolumeSpace(ctx, tenant, tenantSpace, name, nil)
+			if err != nil {
+				return stopped(err)
+			}
+			if lastSpace != nil && space.TotalPhysicalSpace == lastSpace.TotalPhysicalSpace && space.UniqueSpace == lastSpace.UniqueSpace {
+				tflog.Debug(ctx, "volume copy done", "volume", name, "total_physical_space", space.TotalPhysicalSpace)
+				return nil
+			}
+			lastSpace = &space
+		}
+		tflog.Debug(ctx, "waiting for volume copy", "volume", name, "copied", copied, "total_physical_space", space.TotalPhysicalSpace)
+
+		timer := time.NewTimer(volumeCopyPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return stopped(ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
 func (vp *volumeProvider) recoverVolume(
-	ctx context.Context, volume hmrest.Volume, client *hmrest.APIClient, d *schema.ResourceData,
+	ctx context.Context, volume hmrest.Volume, client *Client, d *schema.ResourceData,
 ) error {
 	body := hmrest.VolumePatch{Destroyed: &hmrest.NullableBoolean{Value: false}}
 	op, _, err := client.VolumesApi.UpdateVolume(ctx, body, volume.Tenant.Name, volume.TenantSpace.Name, volume.Name, nil)
@@ -547,20 +729,50 @@ func (vp *volumeProvider) recoverVolume(
 		return err
 	}
 
-	succeeded, err := utilities.WaitOnOperation(ctx, &op, client)
+	succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
 	if err != nil {
 		utilities.TraceError(ctx, err)
 		return err
 	}
 
 	if !succeeded {
-		tflog.Error(ctx, "REST recover failed", "error_message", op.Error_.Message, "PureCode", op.Error_.PureCode, "HttpCode", op.Error_.HttpCode)
-		return errors.New(op.Error_.Message)
+		return utilities.NewRestErrorFromOperation(&op)
 	}
 
 	return nil
 }
 
+// Pure Storage NAA prefix: NAA type 6 followed by the Pure Storage IEEE OUI 24a937
+const volumeNaaPrefix = "624a9370"
+
+// The serial number of a Volume is the vendor specific part of its NAA identifier
+func volumeWwn(serialNumber string) string {
+	if serialNumber == "" {
+		return ""
+	}
+	return volumeNaaPrefix + strings.ToLower(serialNumber)
+}
+
+var volumeSerialNumber = regexp.MustCompile("^[0-9a-f]{24}$")
+
+// Returns the serial number of the Volume with the given WWN, as hosts report it in any case: the bare WWN,
+// prefixed with `0x` or `wwn-0x` like udev names it, or with the NAA type `3` like multipath names it
+func volumeSerialNumberFromWwn(wwn string) (string, error) {
+	naa := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(wwn), "wwn-"), "0x")
+	if strings.HasPrefix(naa, "3"+volumeNaaPrefix) {
+		naa = strings.TrimPrefix(naa, "3")
+	}
+	serialNumber := strings.TrimPrefix(naa, volumeNaaPrefix)
+	if serialNumber == naa || !volumeSerialNumber.MatchString(serialNumber) {
+		return "", fmt.Errorf("%q is not the WWN of a Volume, expected %s followed by 24 hexadecimal digits", wwn, volumeNaaPrefix)
+	}
+	return strings.ToUpper(serialNumber), nil
+}
+
 func getSourceLinkItem(optionName string) string {
 	return fmt.Sprintf("%s.0.%s", optionSourceLink, optionName)
 }
+
+func getRestoreFromItem(optionName string) string {
+	return fmt.Sprintf("%s.0.%s", optionRestoreFrom, optionName)
+}
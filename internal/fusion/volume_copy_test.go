@@ -0,0 +1,119 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+)
+
+// Serves the copied Volume, reporting its source from the given poll on, and the space usage of each space poll
+func testVolumeCopyClient(t *testing.T, copiedFrom int, totalPhysicalSpace func(spacePoll int) int64) (*Client, *int) {
+	pollInterval := volumeCopyPollInterval
+	volumeCopyPollInterval = time.Millisecond
+	t.Cleanup(func() { volumeCopyPollInterval = pollInterval })
+
+	polls, spacePolls := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/tenants/tenant1/tenant-spaces/ts1/volumes/copy":
+			polls++
+			volume := hmrest.Volume{Id: "copy-id", Name: "copy", Size: 1048576}
+			if polls >= copiedFrom {
+				volume.Source = &hmrest.ResourceReference{Name: "source", SelfLink: "/tenants/tenant1/tenant-spaces/ts1/volumes/source"}
+			}
+			json.NewEncoder(w).Encode(volume)
+		case "/tenants/tenant1/tenant-spaces/ts1/volumes/copy/space":
+			spacePolls++
+			json.NewEncoder(w).Encode(hmrest.Space{TotalPhysicalSpace: totalPhysicalSpace(spacePolls)})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return newClient(hmrest.NewAPIClient(&hmrest.Configuration{BasePath: server.URL, DefaultHeader: map[string]string{}, HTTPClient: server.Client()})), &polls
+}
+
+func TestVolumeWaitForCopy_spaceSettles(t *testing.T) {
+	client, polls := testVolumeCopyClient(t, 2, func(spacePoll int) int64 {
+		if spacePoll > 3 {
+			return 300
+		}
+		return int64(spacePoll) * 100
+	})
+
+	vp := &volumeProvider{BaseResourceProvider{ResourceKind: resourceKindVolume}}
+	if err := vp.waitForCopy(context.Background(), client, "tenant1", "ts1", "copy"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The source is reported on the 2nd poll, the space stops growing between the 4th and the 5th
+	if *polls != 5 {
+		t.Errorf("expected the wait to end on the 5th poll, got %d polls", *polls)
+	}
+}
+
+func TestVolumeWaitForCopy_stopsWithContext(t *testing.T) {
+	// The copy keeps growing
+	client, _ := testVolumeCopyClient(t, 1, func(spacePoll int) int64 { return int64(spacePoll) * 100 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	vp := &volumeProvider{BaseResourceProvider{ResourceKind: resourceKindVolume}}
+	err := vp.waitForCopy(ctx, client, "tenant1", "ts1", "copy")
+	if err == nil || !strings.Contains(err.Error(), "stopped waiting for the copy to volume copy") {
+		t.Fatalf("expected the wait to stop with the context, got %v", err)
+	}
+}
+
+func TestVolumeLoad_copySources(t *testing.T) {
+	vp := &volumeProvider{BaseResourceProvider{ResourceKind: resourceKindVolume}}
+	volume := hmrest.Volume{
+		Name:           "copy",
+		Tenant:         &hmrest.TenantRef{Name: "tenant1"},
+		TenantSpace:    &hmrest.TenantSpaceRef{Name: "ts1"},
+		StorageClass:   &hmrest.StorageClassRef{Name: "sc1"},
+		PlacementGroup: &hmrest.PlacementGroupRef{Name: "pg1"},
+		Source:         &hmrest.ResourceReference{Name: "source", SelfLink: "/tenants/tenant1/tenant-spaces/ts1/volumes/source"},
+		SourceVolumeSnapshot: &hmrest.VolumeSnapshotRef{
+			Name:     "vs1",
+			SelfLink: "/tenants/tenant1/tenant-spaces/ts1/snapshots/snap1/volume-snapshots/vs1",
+		},
+	}
+
+	d := resourceVolume().TestResourceData()
+	if err := vp.loadVolume(volume, d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if source := d.Get(optionSource); source != volume.Source.SelfLink {
+		t.Errorf("expected source %q, got %q", volume.Source.SelfLink, source)
+	}
+	if snapshot := d.Get(optionSourceVolumeSnapshot); snapshot != volume.SourceVolumeSnapshot.SelfLink {
+		t.Errorf("expected source volume snapshot %q, got %q", volume.SourceVolumeSnapshot.SelfLink, snapshot)
+	}
+
+	// A Volume which is not a copy has no source
+	volume.Source = nil
+	volume.SourceVolumeSnapshot = nil
+	if err := vp.loadVolume(volume, d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if source := d.Get(optionSource); source != "" {
+		t.Errorf("expected no source, got %q", source)
+	}
+	if snapshot := d.Get(optionSourceVolumeSnapshot); snapshot != "" {
+		t.Errorf("expected no source volume snapshot, got %q", snapshot)
+	}
+}
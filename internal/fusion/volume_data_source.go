@@ -10,6 +10,7 @@ import (
 	"strconv"
 
 	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+	"github.com/antihax/optional"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
@@ -31,6 +32,8 @@ func dataSourceVolume() *schema.Resource {
 	linkSchema[optionVolumeSnapshot].RequiredWith = nil
 	linkSchema[optionVolumeSnapshot].ConflictsWith = nil
 	linkSchema[optionVolume].ConflictsWith = nil
+	delete(volumeSchema, optionWaitForCopy)
+	delete(volumeSchema, optionRestoreFrom)
 
 	ds := &volumeDataSource{}
 
@@ -47,6 +50,15 @@ func dataSourceVolume() *schema.Resource {
 			ValidateFunc: validation.StringIsNotEmpty,
 			Description:  "Tenant space to list Volumes from.",
 		},
+		optionName:             schemaListMatch("Only the Volume with this name."),
+		optionDisplayName:      schemaListMatch("Only the Volumes with this display name."),
+		optionSerialNumber:     schemaListMatch("Only the Volume with this serial number."),
+		optionProtectionPolicy: schemaListMatch("Only the Volumes with this Protection Policy."),
+		optionDestroyed: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Only the destroyed Volumes if `true`, only the Volumes which are not destroyed if `false`.",
+		},
 		optionItems: {
 			Type:     schema.TypeList,
 			Computed: true,
@@ -56,23 +68,54 @@ func dataSourceVolume() *schema.Resource {
 			Description: "List of matching Volumes.",
 		},
 	}
+	withListOptions(dsSchema, "Volumes")
 
 	volumeDataSourceFunctions := NewBaseDataSourceFunctions(resourceKindVolume, ds, dsSchema)
 
 	return volumeDataSourceFunctions.Resource
 }
 
-func (ds *volumeDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *volumeDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	tenant := d.Get(optionTenant).(string)
 	tenantSpace := d.Get(optionTenantSpace).(string)
-	resp, _, err := client.VolumesApi.ListVolumes(ctx, tenant, tenantSpace, nil)
+	listOpts := rdListOptions(d)
+
+	opts := hmrest.VolumesApiListVolumesOpts{
+		Filter:       optionalString(listOpts.filter),
+		Sort:         optionalString(listOpts.sort),
+		Name:         optionalString(rdString(ctx, d, optionName)),
+		DisplayName:  optionalString(rdString(ctx, d, optionDisplayName)),
+		SerialNumber: optionalString(rdString(ctx, d, optionSerialNumber)),
+		Destroyed:    rdOptionalBool(d, optionDestroyed),
+	}
+
+	// The API matches the Protection Policy by its ID only
+	if protectionPolicy := rdString(ctx, d, optionProtectionPolicy); protectionPolicy != "" {
+		policy, _, err := client.ProtectionPoliciesApi.GetProtectionPolicy(ctx, protectionPolicy, nil)
+		if err != nil {
+			return err
+		}
+		opts.ProtectionPolicyId = optional.NewString(policy.Id)
+	}
+
+	var volumes []hmrest.Volume
+	moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		opts.Limit = optional.NewInt32(limit)
+		opts.Offset = optional.NewInt32(offset)
+		resp, _, err := client.VolumesApi.ListVolumes(ctx, tenant, tenantSpace, &opts)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		volumes = append(volumes, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	volumesList := make([]map[string]interface{}, 0, resp.Count)
+	volumesList := make([]map[string]interface{}, 0, len(volumes))
 
-	for _, vol := range resp.Items {
+	for _, vol := range volumes {
 		volInfo := map[string]interface{}{
 			optionName:           vol.Name,
 			optionTenant:         vol.Tenant.Name,
@@ -82,6 +125,9 @@ func (ds *volumeDataSource) ReadDataSource(ctx context.Context, client *hmrest.A
 			optionDisplayName:    vol.DisplayName,
 			optionSize:           strconv.FormatInt(vol.Size, 10),
 			optionSerialNumber:   vol.SerialNumber,
+			optionWwn:            volumeWwn(vol.SerialNumber),
+			optionDestroyed:      vol.Destroyed,
+			optionTimeRemaining:  vol.TimeRemaining,
 			optionCreatedAt:      vol.CreatedAt,
 		}
 		hostNames := []string{}
@@ -99,11 +145,24 @@ func (ds *volumeDataSource) ReadDataSource(ctx context.Context, client *hmrest.A
 				volInfo[optionTargetIscsiAddresses] = vol.Target.Iscsi.Addresses
 			}
 		}
+		if vol.Array != nil {
+			volInfo[optionArray] = vol.Array.Name
+		}
+		if vol.Source != nil {
+			volInfo[optionSource] = vol.Source.SelfLink
+		}
+		if vol.SourceVolumeSnapshot != nil {
+			volInfo[optionSourceVolumeSnapshot] = vol.SourceVolumeSnapshot.SelfLink
+		}
 
 		volumesList = append(volumesList, volInfo)
 	}
 
-	if err := d.Set(optionItems, volumesList); err != nil {
+	err = getFirstError(
+		d.Set(optionItems, volumesList),
+		d.Set(optionMoreItemsRemaining, moreItemsRemaining),
+	)
+	if err != nil {
 		return err
 	}
 
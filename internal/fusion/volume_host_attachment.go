@@ -0,0 +1,290 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// The API sets all the Host Access Policies of a Volume at once. Attachments to the same Volume
+// are serialized within the provider, so that the attachments of one apply do not overwrite each other's
+// read-modify-write. Other Terraform processes are not serialized, see patchVolumeHosts.
+var volumeHostsLocks sync.Map // volume self link -> *sync.Mutex
+
+// How many times the Host Access Policies of a Volume are patched when another process patches them at the same time,
+// and the wait before the first retry, doubling with every next one
+const volumeHostsPatchAttempts = 5
+
+var volumeHostsRetryTime = 2 * time.Second
+
+var errVolumeHostsChanged = errors.New("the host access policies of the volume were changed at the same time")
+
+func lockVolumeHosts(tenant, tenantSpace, volume string) func() {
+	key := fmt.Sprintf("/tenants/%s/tenant-spaces/%s/volumes/%s", tenant, tenantSpace, volume)
+	lock, _ := volumeHostsLocks.LoadOrStore(key, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	return lock.(*sync.Mutex).Unlock
+}
+
+// Implements ResourceProvider
+type volumeHostAttachmentProvider struct {
+	BaseResourceProvider
+}
+
+func schemaVolumeHostAttachment() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		optionTenant: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Tenant.",
+		},
+		optionTenantSpace: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Tenant Space.",
+		},
+		optionVolume: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Volume.",
+		},
+		optionHostAccessPolicy: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The name of the Host Access Policy to connect the Volume to.",
+		},
+	}
+}
+
+// This is our entry point for the Volume Host Attachment resource
+func resourceVolumeHostAttachment() *schema.Resource {
+	p := &volumeHostAttachmentProvider{BaseResourceProvider{ResourceKind: resourceKindVolumeHostAttachment}}
+	volumeHostAttachmentResourceFunctions := NewBaseResourceFunctions(resourceKindVolumeHostAttachment, p)
+	volumeHostAttachmentResourceFunctions.Resource.Description = "Connects a Volume to a single Host Access Policy, " +
+		"leaving the other Host Access Policies of the Volume alone. " +
+		"Set `lifecycle { ignore_changes = [host_access_policies] }` on a `fusion_volume` whose hosts are attached by this resource. " +
+		"Fusion sets all the hosts of a Volume at once, so configurations applied at the same time can still overwrite each other's attachment. " +
+		"The attachment is checked and retried after patching, and an attachment lost anyway is re-created by the next apply."
+	volumeHostAttachmentResourceFunctions.Resource.Schema = schemaVolumeHostAttachment()
+	// Every field identifies the attachment, changing any of them replaces it
+	volumeHostAttachmentResourceFunctions.Resource.UpdateContext = nil
+	volumeHostAttachmentResourceFunctions.Resource.Timeouts.Update = nil
+
+	return volumeHostAttachmentResourceFunctions.Resource
+}
+
+func (p *volumeHostAttachmentProvider) PrepareCreate(ctx context.Context, d *schema.ResourceData) (InvokeWriteAPI, ResourcePost, error) {
+	tenant := rdString(ctx, d, optionTenant)
+	tenantSpace := rdString(ctx, d, optionTenantSpace)
+	volume := rdString(ctx, d, optionVolume)
+	hostAccessPolicy := rdString(ctx, d, optionHostAccessPolicy)
+
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
+		return p.patchVolumeHosts(ctx, client, tenant, tenantSpace, volume, hostAccessPolicy, true)
+	}
+
+	return fn, nil, nil
+}
+
+func (p *volumeHostAttachmentProvider) ReadResource(ctx context.Context, client *Client, d *schema.ResourceData) error {
+	vol, _, err := client.VolumesApi.GetVolume(ctx, rdString(ctx, d, optionTenant), rdString(ctx, d, optionTenantSpace),
+		rdString(ctx, d, optionVolume), nil)
+	if err != nil {
+		return err
+	}
+
+	hostAccessPolicy := rdString(ctx, d, optionHostAccessPolicy)
+	if !volumeHasHost(vol, hostAccessPolicy) {
+		tflog.Warn(ctx, "host access policy was detached from the volume outside of Terraform", "volume", vol.Name,
+			"host_access_policy", hostAccessPolicy)
+		d.SetId("")
+		return nil
+	}
+
+	return p.loadVolumeHostAttachment(vol, hostAccessPolicy, d)
+}
+
+func (p *volumeHostAttachmentProvider) PrepareDelete(ctx context.Context, client *Client, d *schema.ResourceData) (InvokeWriteAPI, error) {
+	tenant := rdString(ctx, d, optionTenant)
+	tenantSpace := rdString(ctx, d, optionTenantSpace)
+	volume := rdString(ctx, d, optionVolume)
+	hostAccessPolicy := rdString(ctx, d, optionHostAccessPolicy)
+
+	fn := func(ctx context.Context, client *Client, body RequestSpec) (*hmrest.Operation, error) {
+		return p.patchVolumeHosts(ctx, client, tenant, tenantSpace, volume, hostAccessPolicy, false)
+	}
+
+	return fn, nil
+}
+
+func (p *volumeHostAttachmentProvider) ImportResource(ctx context.Context, client *Client, d *schema.ResourceData) ([]*schema.ResourceData, error) {
+	orderedRequiredGroupNames := []string{
+		resourceGroupNameTenant,
+		resourceGroupNameTenantSpace,
+		resourceGroupNameVolume,
+		resourceGroupNameHostAccessPolicy,
+	}
+	// The ID is user provided value - we expect self link of the volume followed by the host access policy
+	parsedSelfLink, err := utilities.ParseSelfLink(d.Id(), orderedRequiredGroupNames)
+	if err != nil {
+		return nil, fmt.Errorf("invalid volume_host_attachment import path. " +
+			"Expected path in format '/tenants/<tenant>/tenant-spaces/<tenant-space>/volumes/<volume>/host-access-policies/<host-access-policy>'")
+	}
+
+	vol, _, err := client.VolumesApi.GetVolume(ctx, parsedSelfLink[resourceGroupNameTenant], parsedSelfLink[resourceGroupNameTenantSpace],
+		parsedSelfLink[resourceGroupNameVolume], nil)
+	if err != nil {
+		utilities.TraceError(ctx, err)
+		return nil, err
+	}
+
+	hostAccessPolicy := parsedSelfLink[resourceGroupNameHostAccessPolicy]
+	if !volumeHasHost(vol, hostAccessPolicy) {
+		return nil, fmt.Errorf("volume %s is not connected to host access policy %s", vol.Name, hostAccessPolicy)
+	}
+
+	err = p.loadVolumeHostAttachment(vol, hostAccessPolicy, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// Reads the current Host Access Policies of the Volume and adds or removes the given one, keeping the others.
+// Waits for the patch, so that the next attachment to the same Volume starts from its result.
+//
+// Another Terraform process patching the same Volume at the same time may have read the hosts before the patch,
+// and overwrite them. The hosts are read again after the patch, and patched again when the given one or one of
+// the others is missing. A patch overwriting them after this check is only noticed by the next refresh.
+func (p *volumeHostAttachmentProvider) patchVolumeHosts(
+	ctx context.Context, client *Client, tenant, tenantSpace, volume, hostAccessPolicy string, attach bool,
+) (*hmrest.Operation, error) {
+	unlock := lockVolumeHosts(tenant, tenantSpace, volume)
+	defer unlock()
+
+	op := &hmrest.Operation{}
+	err := utilities.Retry(ctx, volumeHostsRetryTime, 1.0, volumeHostsPatchAttempts, "patch volume hosts", func() (bool, error) {
+		var err error
+		var hosts string
+		op, hosts, err = p.patchVolumeHostsOnce(ctx, client, tenant, tenantSpace, volume, hostAccessPolicy, attach)
+		if err != nil {
+			return true, err
+		}
+
+		vol, _, err := client.VolumesApi.GetVolume(ctx, tenant, tenantSpace, volume, nil)
+		if err != nil {
+			return true, err
+		}
+		if lost := volumeHostsLost(vol, hosts); volumeHasHost(vol, hostAccessPolicy) != attach || len(lost) != 0 {
+			tflog.Warn(ctx, "volume hosts changed by another patch, patching again", "volume", volume,
+				"host_access_policy", hostAccessPolicy, "attach", attach, "lost_host_access_policies", lost)
+			return false, errVolumeHostsChanged
+		}
+		return true, nil
+	})
+	if errors.Is(err, errVolumeHostsChanged) {
+		err = fmt.Errorf("%w after %d attempts, check the other configurations managing volume %s", err, volumeHostsPatchAttempts, volume)
+	}
+	return op, err
+}
+
+// Patches the Host Access Policies of the Volume once, and returns the comma separated hosts it set
+func (p *volumeHostAttachmentProvider) patchVolumeHostsOnce(
+	ctx context.Context, client *Client, tenant, tenantSpace, volume, hostAccessPolicy string, attach bool,
+) (*hmrest.Operation, string, error) {
+	vol, _, err := client.VolumesApi.GetVolume(ctx, tenant, tenantSpace, volume, nil)
+	if err != nil {
+		return &hmrest.Operation{}, "", err
+	}
+
+	hosts := volumeHostsWith(vol, hostAccessPolicy, attach)
+	tflog.Debug(ctx, "patching volume hosts", "volume", volume, "host_access_policy", hostAccessPolicy, "attach", attach,
+		"host_access_policies", hosts)
+
+	op, _, err := client.VolumesApi.UpdateVolume(ctx, hmrest.VolumePatch{
+		HostAccessPolicies: &hmrest.NullableString{Value: hosts},
+	}, tenant, tenantSpace, volume, nil)
+	utilities.TraceOperation(ctx, &op, "Patching Volume Hosts")
+	if err != nil {
+		return &op, hosts, err
+	}
+
+	succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
+	if err == nil && !succeeded {
+		err = utilities.NewRestErrorFromOperation(&op)
+	}
+	return &op, hosts, err
+}
+
+// The Volume ID cannot identify the attachment, the Volume and its other attachments have the same one
+func volumeHostAttachmentId(vol hmrest.Volume, hostAccessPolicy string) string {
+	return vol.SelfLink + "/host-access-policies/" + hostAccessPolicy
+}
+
+func (p *volumeHostAttachmentProvider) loadVolumeHostAttachment(vol hmrest.Volume, hostAccessPolicy string, d *schema.ResourceData) error {
+	d.SetId(volumeHostAttachmentId(vol, hostAccessPolicy))
+	return getFirstError(
+		d.Set(optionTenant, vol.Tenant.Name),
+		d.Set(optionTenantSpace, vol.TenantSpace.Name),
+		d.Set(optionVolume, vol.Name),
+		d.Set(optionHostAccessPolicy, hostAccessPolicy),
+	)
+}
+
+func volumeHasHost(vol hmrest.Volume, hostAccessPolicy string) bool {
+	for _, hap := range vol.HostAccessPolicies {
+		if hap.Name == hostAccessPolicy {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the comma separated Host Access Policies of the Volume with the given one attached or detached
+func volumeHostsWith(vol hmrest.Volume, hostAccessPolicy string, attach bool) string {
+	hosts := []string{}
+	for _, hap := range vol.HostAccessPolicies {
+		if hap.Name != hostAccessPolicy {
+			hosts = append(hosts, hap.Name)
+		}
+	}
+	if attach {
+		hosts = append(hosts, hostAccessPolicy)
+	}
+	return strings.Join(hosts, ",")
+}
+
+// Returns the comma separated hosts which the Volume is missing
+func volumeHostsLost(vol hmrest.Volume, hosts string) []string {
+	lost := []string{}
+	for _, host := range strings.Split(hosts, ",") {
+		if host != "" && !volumeHasHost(vol, host) {
+			lost = append(lost, host)
+		}
+	}
+	return lost
+}
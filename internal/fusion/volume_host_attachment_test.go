@@ -0,0 +1,234 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+func TestVolumeHostsWith(t *testing.T) {
+	vol := hmrest.Volume{HostAccessPolicies: []hmrest.HostAccessPolicyRef{{Name: "host0"}, {Name: "host1"}}}
+
+	for _, test := range []struct {
+		hostAccessPolicy string
+		attach           bool
+		expected         string
+	}{
+		{"host2", true, "host0,host1,host2"},
+		{"host1", true, "host0,host1"},
+		{"host0", false, "host1"},
+		{"host2", false, "host0,host1"},
+	} {
+		if got := volumeHostsWith(vol, test.hostAccessPolicy, test.attach); got != test.expected {
+			t.Errorf("%s attach=%t: expected %q, got %q", test.hostAccessPolicy, test.attach, test.expected, got)
+		}
+	}
+}
+
+// Serves the hosts of a Volume, letting another process patch them right after each of our patches
+type testVolumeHostsAPI struct {
+	VolumesAPI
+	hosts       string
+	otherPatch  func(hosts string) string
+	patchedHost []string
+}
+
+func (api *testVolumeHostsAPI) GetVolume(ctx context.Context, tenantName, tenantSpaceName, volumeName string,
+	opts *hmrest.VolumesApiGetVolumeOpts) (hmrest.Volume, *http.Response, error) {
+	vol := hmrest.Volume{
+		Id:          "vol-id",
+		Name:        volumeName,
+		SelfLink:    "/tenants/" + tenantName + "/tenant-spaces/" + tenantSpaceName + "/volumes/" + volumeName,
+		Tenant:      &hmrest.TenantRef{Name: tenantName},
+		TenantSpace: &hmrest.TenantSpaceRef{Name: tenantSpaceName},
+	}
+	for _, host := range strings.Split(api.hosts, ",") {
+		if host != "" {
+			vol.HostAccessPolicies = append(vol.HostAccessPolicies, hmrest.HostAccessPolicyRef{Name: host})
+		}
+	}
+	return vol, nil, nil
+}
+
+func (api *testVolumeHostsAPI) UpdateVolume(ctx context.Context, body hmrest.VolumePatch, tenantName, tenantSpaceName, volumeName string,
+	opts *hmrest.VolumesApiUpdateVolumeOpts) (hmrest.Operation, *http.Response, error) {
+	api.hosts = body.HostAccessPolicies.Value
+	api.patchedHost = append(api.patchedHost, api.hosts)
+	if api.otherPatch != nil {
+		api.hosts = api.otherPatch(api.hosts)
+	}
+	return hmrest.Operation{Id: "op-id", Status: "Succeeded"}, nil, nil
+}
+
+func TestVolumeHostAttachment_concurrentPatch(t *testing.T) {
+	retryTime := volumeHostsRetryTime
+	volumeHostsRetryTime = time.Millisecond
+	t.Cleanup(func() { volumeHostsRetryTime = retryTime })
+
+	p := &volumeHostAttachmentProvider{BaseResourceProvider{ResourceKind: resourceKindVolumeHostAttachment}}
+
+	// The other process read the hosts before our first patch, and overwrites it once
+	otherPatches := 0
+	api := &testVolumeHostsAPI{hosts: "host0", otherPatch: func(hosts string) string {
+		otherPatches++
+		if otherPatches == 1 {
+			return "host0,host2"
+		}
+		return hosts
+	}}
+	if _, err := p.patchVolumeHosts(context.Background(), &Client{VolumesApi: api}, "tenant1", "ts1", "vol", "host1", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"host0,host1", "host0,host2,host1"}; !reflect.DeepEqual(api.patchedHost, expected) {
+		t.Errorf("expected patches %v, got %v", expected, api.patchedHost)
+	}
+
+	// The other process keeps removing our host
+	api = &testVolumeHostsAPI{hosts: "host0", otherPatch: func(string) string { return "host0" }}
+	_, err := p.patchVolumeHosts(context.Background(), &Client{VolumesApi: api}, "tenant1", "ts1", "vol", "host1", true)
+	if !errors.Is(err, errVolumeHostsChanged) {
+		t.Errorf("expected the patch to give up, got %v", err)
+	}
+	if len(api.patchedHost) != volumeHostsPatchAttempts {
+		t.Errorf("expected %d patches, got %v", volumeHostsPatchAttempts, api.patchedHost)
+	}
+}
+
+func TestVolumeHostAttachment_id(t *testing.T) {
+	p := &volumeHostAttachmentProvider{BaseResourceProvider{ResourceKind: resourceKindVolumeHostAttachment}}
+	api := &testVolumeHostsAPI{hosts: "host0,host1"}
+	d := resourceVolumeHostAttachment().TestResourceData()
+	d.Set(optionTenant, "tenant1")
+	d.Set(optionTenantSpace, "ts1")
+	d.Set(optionVolume, "vol")
+	d.Set(optionHostAccessPolicy, "host1")
+	d.SetId("vol-id")
+
+	if err := p.ReadResource(context.Background(), &Client{VolumesApi: api}, d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "/tenants/tenant1/tenant-spaces/ts1/volumes/vol/host-access-policies/host1"; d.Id() != expected {
+		t.Errorf("expected ID %q, got %q", expected, d.Id())
+	}
+}
+
+func TestAccVolumeHostAttachment_basic(t *testing.T) {
+	utilities.CheckTestSkip(t)
+
+	tenant := acctest.RandomWithPrefix("tenant-attachTest")
+	ts := acctest.RandomWithPrefix("ts-attachTest")
+	pg := acctest.RandomWithPrefix("pg-attachTest")
+	storageService := acctest.RandomWithPrefix("ss-attachTest")
+	storageClass := acctest.RandomWithPrefix("sc-attachTest")
+	volume := acctest.RandomWithPrefix("vol-attachTest")
+	host0 := testFusionResource{RName: "host0", Name: acctest.RandomWithPrefix("host0-attachTest")}
+	host1 := testFusionResource{RName: "host1", Name: acctest.RandomWithPrefix("host1-attachTest")}
+
+	commonConfig := "" +
+		testTenantConfig(tenant, tenant, "tenant display name") +
+		testTenantSpaceConfigWithRefs(ts, "ts display name", ts, tenant) +
+		testPlacementGroupConfigWithRefsNoArray(pg, pg, "pg display name", tenant, ts, preexistingRegion, preexistingAvailabilityZone, storageService, true) +
+		testHostAccessPolicyConfig(host0.RName, host0.Name, "host display name", randIQN(), "linux") +
+		testHostAccessPolicyConfig(host1.RName, host1.Name, "host display name", randIQN(), "linux") +
+		testStorageServiceConfigNoDisplayName(storageService, storageService, []string{"flash-array-x"}) +
+		testStorageClassConfigNoDisplayName(storageClass, storageClass, storageService, 2*testSizeLimit, testIopsLimit, testBandwidthLimit) +
+		fmt.Sprintf(`
+resource "fusion_volume" "vol" {
+	name                = "%[1]s"
+	tenant              = fusion_tenant.%[2]s.name
+	tenant_space        = fusion_tenant_space.%[3]s.name
+	storage_class       = fusion_storage_class.%[4]s.name
+	placement_group     = fusion_placement_group.%[5]s.name
+	size                = 1048576
+	eradicate_on_delete = true
+
+	lifecycle {
+		ignore_changes = [host_access_policies]
+	}
+}
+`, volume, tenant, ts, storageClass, pg)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testCheckVolumeDelete,
+		Steps: []resource.TestStep{
+			// Attachments made in parallel must not overwrite each other
+			{
+				Config: commonConfig + testVolumeHostAttachmentConfig(host0) + testVolumeHostAttachmentConfig(host1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("fusion_volume_host_attachment."+host0.RName, "host_access_policy", host0.Name),
+					resource.TestCheckResourceAttr("fusion_volume_host_attachment."+host1.RName, "host_access_policy", host1.Name),
+					testCheckVolumeHosts("fusion_volume.vol", host0.Name, host1.Name),
+				),
+			},
+			// Detaching one host keeps the other
+			{
+				Config: commonConfig + testVolumeHostAttachmentConfig(host1),
+				Check:  testCheckVolumeHosts("fusion_volume.vol", host1.Name),
+			},
+			{
+				ResourceName:      "fusion_volume_host_attachment." + host1.RName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("/tenants/%s/tenant-spaces/%s/volumes/%s/host-access-policies/%s", tenant, ts, volume, host1.Name),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testVolumeHostAttachmentConfig(host testFusionResource) string {
+	return fmt.Sprintf(`
+resource "fusion_volume_host_attachment" "%[1]s" {
+	tenant             = fusion_volume.vol.tenant
+	tenant_space       = fusion_volume.vol.tenant_space
+	volume             = fusion_volume.vol.name
+	host_access_policy = fusion_host_access_policy.%[1]s.name
+}
+`, host.RName)
+}
+
+// Checks the Host Access Policies the Volume is connected to in Fusion, not in the state
+func testCheckVolumeHosts(rName string, expectedHosts ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		volume, ok := s.RootModule().Resources[rName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rName)
+		}
+
+		directVolume, _, err := testAccProvider.Meta().(*Client).VolumesApi.GetVolumeById(context.Background(), volume.Primary.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		directHosts := []string{}
+		for _, hap := range directVolume.HostAccessPolicies {
+			directHosts = append(directHosts, hap.Name)
+		}
+		sort.Strings(directHosts)
+		sort.Strings(expectedHosts)
+		if !reflect.DeepEqual(directHosts, expectedHosts) {
+			return fmt.Errorf("expected volume hosts %v, got %v", expectedHosts, directHosts)
+		}
+
+		return nil
+	}
+}
@@ -67,6 +67,14 @@ func dataSourceVolumeSnapshot() *schema.Resource {
 			ValidateFunc: validation.StringIsNotEmpty,
 			Description:  "ID of the Placement Group.",
 		},
+		optionName:               schemaListMatch("Only the Volume Snapshot with this name."),
+		optionDisplayName:        schemaListMatch("Only the Volume Snapshots with this display name."),
+		optionVolumeSerialNumber: schemaListMatch("Only the Volume Snapshots of the Volume with this serial number."),
+		optionDestroyed: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Only the destroyed Volume Snapshots if `true`, only the Volume Snapshots which are not destroyed if `false`.",
+		},
 		optionItems: {
 			Type:     schema.TypeList,
 			Computed: true,
@@ -155,6 +163,7 @@ func dataSourceVolumeSnapshot() *schema.Resource {
 			Description: "List of matching Volume Snapshots.",
 		},
 	}
+	withListOptions(dsSchema, "Volume Snapshots")
 
 	volumeSnapshotDataSourceFunctions := NewBaseDataSourceFunctions(resourceKindVolumeSnapshot, ds, dsSchema)
 	// Override default description as there's no resource for this data source.
@@ -163,7 +172,7 @@ func dataSourceVolumeSnapshot() *schema.Resource {
 	return volumeSnapshotDataSourceFunctions.Resource
 }
 
-func (ds *volumeSnapshotDataSource) ReadDataSource(ctx context.Context, client *hmrest.APIClient, d *schema.ResourceData) error {
+func (ds *volumeSnapshotDataSource) ReadDataSource(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	tenant, _ := d.Get(optionTenant).(string)
 	tenantSpace, _ := d.Get(optionTenantSpace).(string)
 	snapshot, _ := d.Get(optionSnapshot).(string)
@@ -172,7 +181,16 @@ func (ds *volumeSnapshotDataSource) ReadDataSource(ctx context.Context, client *
 	placementGroupId, _ := d.Get(optionPlacementGroupId).(string)
 	volumeId, _ := d.Get(optionVolumeId).(string)
 
-	var localOpts hmrest.VolumeSnapshotsApiListVolumeSnapshotsOpts
+	listOpts := rdListOptions(d)
+
+	localOpts := hmrest.VolumeSnapshotsApiListVolumeSnapshotsOpts{
+		Filter:             optionalString(listOpts.filter),
+		Sort:               optionalString(listOpts.sort),
+		Name:               optionalString(rdString(ctx, d, optionName)),
+		DisplayName:        optionalString(rdString(ctx, d, optionDisplayName)),
+		VolumeSerialNumber: optionalString(rdString(ctx, d, optionVolumeSerialNumber)),
+		Destroyed:          rdOptionalBool(d, optionDestroyed),
+	}
 	if protectionPolicyId != "" {
 		localOpts.ProtectionPolicyId = optional.NewString(protectionPolicyId)
 	}
@@ -190,13 +208,23 @@ func (ds *volumeSnapshotDataSource) ReadDataSource(ctx context.Context, client *
 		localOpts.CreatedAt = optional.NewInt64(createdAt)
 	}
 
-	resp, _, err := client.VolumeSnapshotsApi.ListVolumeSnapshots(ctx, tenant, tenantSpace, snapshot, &localOpts)
+	var volumeSnapshots []hmrest.VolumeSnapshot
+	moreItemsRemaining, err := listOpts.listAll(ctx, func(limit, offset int32) (int, int32, bool, error) {
+		localOpts.Limit = optional.NewInt32(limit)
+		localOpts.Offset = optional.NewInt32(offset)
+		resp, _, err := client.VolumeSnapshotsApi.ListVolumeSnapshots(ctx, tenant, tenantSpace, snapshot, &localOpts)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		volumeSnapshots = append(volumeSnapshots, resp.Items...)
+		return len(resp.Items), resp.Count, resp.MoreItemsRemaining, nil
+	})
 	if err != nil {
 		return err
 	}
-	volumeSnapshotList := make([]map[string]interface{}, resp.Count)
+	volumeSnapshotList := make([]map[string]interface{}, len(volumeSnapshots))
 
-	for i, volumeSnapshot := range resp.Items {
+	for i, volumeSnapshot := range volumeSnapshots {
 		volumeSnapshotList[i] = map[string]interface{}{
 			optionName:               volumeSnapshot.Name,
 			optionDisplayName:        volumeSnapshot.DisplayName,
@@ -220,7 +248,11 @@ func (ds *volumeSnapshotDataSource) ReadDataSource(ctx context.Context, client *
 		}
 	}
 
-	if err := d.Set(optionItems, volumeSnapshotList); err != nil {
+	err = getFirstError(
+		d.Set(optionItems, volumeSnapshotList),
+		d.Set(optionMoreItemsRemaining, moreItemsRemaining),
+	)
+	if err != nil {
 		return err
 	}
 
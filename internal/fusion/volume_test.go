@@ -19,8 +19,41 @@ import (
 	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
+func TestLoadVolume_placementAndIdentity(t *testing.T) {
+	vp := &volumeProvider{BaseResourceProvider{ResourceKind: resourceKindVolume}}
+	d := resourceVolume().TestResourceData()
+
+	err := vp.loadVolume(hmrest.Volume{
+		Name:           "vol1",
+		Size:           1048576,
+		SerialNumber:   "8F3A1F5B4F9A4D1B00011C1F",
+		Tenant:         &hmrest.TenantRef{Name: "tenant1"},
+		TenantSpace:    &hmrest.TenantSpaceRef{Name: "ts1"},
+		StorageClass:   &hmrest.StorageClassRef{Name: "sc1"},
+		PlacementGroup: &hmrest.PlacementGroupRef{Name: "pg1"},
+		Array:          &hmrest.ArrayRef{Name: "array1"},
+		Destroyed:      true,
+		TimeRemaining:  86400000,
+	}, d)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for option, expected := range map[string]interface{}{
+		optionWwn:           "624a93708f3a1f5b4f9a4d1b00011c1f",
+		optionArray:         "array1",
+		optionDestroyed:     true,
+		optionTimeRemaining: 86400000,
+	} {
+		if got := d.Get(option); got != expected {
+			t.Errorf("expected %s %v, got %v", option, expected, got)
+		}
+	}
+}
+
 func TestAccVolume_basic(t *testing.T) {
 	utilities.CheckTestSkip(t)
+	testAccHttpFixture(t)
 
 	tenant := acctest.RandomWithPrefix("tenant-volTest")
 	ts := acctest.RandomWithPrefix("ts-volTest")
@@ -108,7 +141,7 @@ func TestAccVolume_basic(t *testing.T) {
 		return step
 	}
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccHttpFixtureTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -125,6 +158,7 @@ func TestAccVolume_basic(t *testing.T) {
 
 func TestAccVolume_eradicate(t *testing.T) {
 	utilities.CheckTestSkip(t)
+	testAccHttpFixture(t)
 
 	eradicate := true
 	volState, commonConfig := generateVolumeTestConfigAndCommonTFConfig(&eradicate, []string{"flash-array-x"}, nil)
@@ -132,7 +166,7 @@ func TestAccVolume_eradicate(t *testing.T) {
 	volState1 := volState
 	volState1.Eradicate = nil
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccHttpFixtureTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -165,12 +199,13 @@ func TestAccVolume_eradicate(t *testing.T) {
 
 func TestAccVolume_destroyOnly(t *testing.T) {
 	utilities.CheckTestSkip(t)
+	testAccHttpFixture(t)
 
 	volState, commonConfig := generateVolumeTestConfigAndCommonTFConfig(nil, []string{"flash-array-x"}, nil)
 	ctx := setupTestCtx(t)
 	hmClient := testAccPreCheckWithReturningClient(ctx, t)
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccHttpFixtureTest(t, resource.TestCase{
 		ProviderFactories: testAccProvidersFactory,
 		CheckDestroy:      testCheckVolumeDelete,
 		Steps: []resource.TestStep{
@@ -201,6 +236,7 @@ func TestAccVolume_destroyOnly(t *testing.T) {
 
 func TestAccVolume_copyFromVolume(t *testing.T) {
 	utilities.CheckTestSkip(t)
+	testAccHttpFixture(t)
 
 	eradicate := true
 	volState, commonConfig := generateVolumeTestConfigAndCommonTFConfig(&eradicate, []string{"flash-array-x"}, nil)
@@ -213,13 +249,15 @@ func TestAccVolume_copyFromVolume(t *testing.T) {
 		"tenant_space": volState.TenantSpace,
 		"volume":       volState.Name,
 	}
+	volState1.WaitForCopy = true
 
 	volState2 := volState1
 	volState2.SourceLink = nil
+	volState2.WaitForCopy = false
 
 	volStateResource := "fusion_volume." + volState1.RName
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccHttpFixtureTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -237,7 +275,10 @@ func TestAccVolume_copyFromVolume(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testVolumeExists("fusion_volume."+volState.RName, t),
 					testVolumeExists("fusion_volume."+volState1.RName, t),
-					testCheckVolumeAttributes(volStateResource, volState1)),
+					testCheckVolumeAttributes(volStateResource, volState1),
+					resource.TestCheckResourceAttr(volStateResource, "source",
+						fmt.Sprintf("/tenants/%s/tenant-spaces/%s/volumes/%s", volState.Tenant, volState.TenantSpace, volState.Name)),
+				),
 			},
 			{
 				// Destroy the copied volume
@@ -266,6 +307,7 @@ func TestAccVolume_copyFromVolume(t *testing.T) {
 
 func TestAccVolume_copyFromSnapshot(t *testing.T) {
 	utilities.CheckTestSkip(t)
+	testAccHttpFixture(t)
 
 	eradicate := true
 	volState, commonConfig := generateVolumeTestConfigAndCommonTFConfig(&eradicate, []string{"flash-array-x"}, nil)
@@ -284,7 +326,7 @@ func TestAccVolume_copyFromSnapshot(t *testing.T) {
 		"volume_snapshot": volState.Name,
 	}
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccHttpFixtureTest(t, resource.TestCase{
 		ProviderFactories: testAccProvidersFactory,
 		CheckDestroy:      testCheckVolumeDelete,
 		Steps: []resource.TestStep{
@@ -331,15 +373,95 @@ func TestAccVolume_copyFromSnapshot(t *testing.T) {
 	})
 }
 
+func TestAccVolume_restoreFrom(t *testing.T) {
+	utilities.CheckTestSkip(t)
+	testAccHttpFixture(t)
+
+	eradicate := true
+	volState, commonConfig := generateVolumeTestConfigAndCommonTFConfig(&eradicate, []string{"flash-array-x"}, nil)
+	volStateResource := "fusion_volume." + volState.RName
+
+	snapshotName := acctest.RandomWithPrefix("snapshot")
+	ctx := setupTestCtx(t)
+	hmClient := testAccPreCheckWithReturningClient(ctx, t)
+
+	volState1 := volState
+	volState1.RestoreFrom = map[string]string{
+		"snapshot":        snapshotName,
+		"volume_snapshot": volState.Name,
+		"trigger":         "1",
+	}
+	volState2 := volState1
+	volState2.RestoreFrom = map[string]string{
+		"snapshot":        snapshotName,
+		"volume_snapshot": volState.Name,
+		"trigger":         "2",
+	}
+	sourceVolumeSnapshot := fmt.Sprintf("/tenants/%s/tenant-spaces/%s/snapshots/%s/volume-snapshots/%s",
+		volState.Tenant, volState.TenantSpace, snapshotName, volState.Name)
+
+	var serialNumber string
+
+	testAccHttpFixtureTest(t, resource.TestCase{
+		ProviderFactories: testAccProvidersFactory,
+		CheckDestroy:      testCheckVolumeDelete,
+		Steps: []resource.TestStep{
+			{
+				// Create a volume and a snapshot of it
+				Config: commonConfig + testVolumeConfig(volState),
+				Check: func(s *terraform.State) error {
+					if err := testVolumeExists(volStateResource, t)(s); err != nil {
+						return err
+					}
+					serialNumber = s.RootModule().Resources[volStateResource].Primary.Attributes["serial_number"]
+
+					snapPost := hmrest.SnapshotPost{
+						Name:    snapshotName,
+						Volumes: []string{volState.Name},
+					}
+
+					testVolumeDoOperation(t, ctx, hmClient, "snapshot-create")(
+						hmClient.SnapshotsApi.CreateSnapshot(ctx, snapPost, volState.Tenant, volState.TenantSpace, nil),
+					)
+
+					return nil
+				},
+			},
+			{
+				// Restore the volume in place from the snapshot
+				Config: commonConfig + testVolumeConfig(volState1),
+				Check: resource.ComposeTestCheckFunc(
+					testVolumeExists(volStateResource, t),
+					testCheckVolumeAttributes(volStateResource, volState1),
+					resource.TestCheckResourceAttr(volStateResource, "source_volume_snapshot", sourceVolumeSnapshot),
+					func(s *terraform.State) error {
+						return resource.TestCheckResourceAttr(volStateResource, "serial_number", serialNumber)(s)
+					},
+				),
+			},
+			{
+				// Restore again with a new trigger
+				Config: commonConfig + testVolumeConfig(volState2),
+				Check: resource.ComposeTestCheckFunc(
+					testVolumeExists(volStateResource, t),
+					testCheckVolumeAttributes(volStateResource, volState2),
+					resource.TestCheckResourceAttr(volStateResource, "source_volume_snapshot", sourceVolumeSnapshot),
+				),
+			},
+		},
+	})
+}
+
 func TestAccVolume_recovery(t *testing.T) {
 	utilities.CheckTestSkip(t)
+	testAccHttpFixture(t)
 
 	eradicate := true
 	volState, commonConfig := generateVolumeTestConfigAndCommonTFConfig(&eradicate, []string{"flash-array-x"}, nil)
 	ctx := setupTestCtx(t)
 	hmClient := testAccPreCheckWithReturningClient(ctx, t)
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccHttpFixtureTest(t, resource.TestCase{
 		ProviderFactories: testAccProvidersFactory,
 		CheckDestroy:      testCheckVolumeDelete,
 		Steps: []resource.TestStep{
@@ -364,7 +486,7 @@ func TestAccVolume_recovery(t *testing.T) {
 				ImportStateId:           fmt.Sprintf("/tenants/%s/tenant-spaces/%s/volumes/%s", volState.Tenant, volState.TenantSpace, volState.Name),
 				ResourceName:            "fusion_volume." + volState.RName,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"eradicate_on_delete"},
+				ImportStateVerifyIgnore: []string{"eradicate_on_delete", "deletion_protection"},
 			},
 		},
 	})
@@ -382,7 +504,7 @@ func testVolumeExists(rName string, t *testing.T) resource.TestCheckFunc {
 		}
 		attrs := volume.Primary.Attributes
 
-		directVolume, _, err := testAccProvider.Meta().(*hmrest.APIClient).VolumesApi.GetVolumeById(context.Background(), attrs["id"], nil)
+		directVolume, _, err := testAccProvider.Meta().(*Client).VolumesApi.GetVolumeById(context.Background(), attrs["id"], nil)
 		if err != nil {
 			return fmt.Errorf("go client returned error while searching for %s by id: %s. Error: %s", attrs["name"], attrs["id"], err)
 		}
@@ -449,6 +571,8 @@ type testVolume struct {
 	Eradicate            *bool
 	SourceLink           map[string]string
 	StorageService       string
+	WaitForCopy          bool
+	RestoreFrom          map[string]string
 }
 
 type testFusionResource struct {
@@ -485,6 +609,11 @@ func testVolumeConfigCopy(vol testVolume) string {
 		hapField = fmt.Sprintf("host_access_policies = [%s]", strings.Join(hapList, ","))
 	}
 
+	waitForCopy := ""
+	if vol.WaitForCopy {
+		waitForCopy = "wait_for_copy = true"
+	}
+
 	return fmt.Sprintf(`
 resource "fusion_volume" "%[1]s" {
 		name          = "%[2]s"
@@ -497,9 +626,10 @@ resource "fusion_volume" "%[1]s" {
 		placement_group = fusion_placement_group.%[9]s.name
 		%[10]s
 		%[11]s
+		%[12]s
 }`, vol.RName, vol.Name, vol.DisplayName, vol.ProtectionPolicyName,
 		vol.Tenant, vol.TenantSpace, vol.StorageClassName,
-		hapField, vol.PlacementGroup, eradicate, sourceLink)
+		hapField, vol.PlacementGroup, eradicate, sourceLink, waitForCopy)
 }
 
 func testVolumeConfig(vol testVolume) string {
@@ -523,6 +653,15 @@ func testVolumeConfig(vol testVolume) string {
 		hapField = fmt.Sprintf("host_access_policies = [%s]", strings.Join(hapList, ","))
 	}
 
+	restoreFrom := ""
+	if vol.RestoreFrom != nil {
+		restoreFrom = fmt.Sprintf(`restore_from {
+			snapshot        = "%[1]s"
+			volume_snapshot = "%[2]s"
+			trigger         = "%[3]s"
+		}`, vol.RestoreFrom["snapshot"], vol.RestoreFrom["volume_snapshot"], vol.RestoreFrom["trigger"])
+	}
+
 	return fmt.Sprintf(`
 resource "fusion_volume" "%[1]s" {
 		name          = "%[2]s"
@@ -535,14 +674,15 @@ resource "fusion_volume" "%[1]s" {
 		%[9]s
 		placement_group = fusion_placement_group.%[10]s.name
 		%[11]s
+		%[12]s
 }`, vol.RName, vol.Name, vol.DisplayName, protectionPolicy,
 		vol.Tenant, vol.TenantSpace, vol.StorageClassName,
-		vol.Size, hapField, vol.PlacementGroup, eradicate)
+		vol.Size, hapField, vol.PlacementGroup, eradicate, restoreFrom)
 }
 
 func testCheckVolumeDelete(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
-		client := testAccProvider.Meta().(*hmrest.APIClient)
+		client := testAccProvider.Meta().(*Client)
 
 		if rs.Type != "fusion_volume" {
 			continue
@@ -567,7 +707,7 @@ func testCheckVolumeDelete(s *terraform.State) error {
 
 func testCheckVolumeDestroy(name, tenantName, tenantSpaceName string) func(s *terraform.State) error {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*hmrest.APIClient)
+		client := testAccProvider.Meta().(*Client)
 
 		volume, resp, err := client.VolumesApi.GetVolume(context.Background(), tenantName, tenantSpaceName, name, nil)
 		if err != nil {
@@ -596,6 +736,9 @@ func testCheckVolumeAttributes(resourceName string, volState testVolume) resourc
 		resource.TestCheckResourceAttr(resourceName, "storage_class", volState.StorageClassName),
 		resource.TestCheckResourceAttr(resourceName, "placement_group", volState.PlacementGroup),
 		resource.TestCheckResourceAttr(resourceName, "host_access_policies.#", fmt.Sprintf("%d", len(volState.Hosts))),
+		resource.TestCheckResourceAttr(resourceName, "destroyed", "false"),
+		resource.TestCheckResourceAttrSet(resourceName, "array"),
+		resource.TestCheckResourceAttrSet(resourceName, "wwn"),
 	)
 }
 
@@ -607,7 +750,7 @@ func testVolumeDoOperation(
 		if err != nil {
 			t.Errorf("%s: %s", userMessage, err)
 		}
-		succeeded, err := utilities.WaitOnOperation(ctx, &op, hmClient)
+		succeeded, err := utilities.WaitOnOperation(ctx, &op, hmClient.OperationsApi)
 		if !succeeded || err != nil {
 			t.Errorf("operation failure %s succeeded:%v error:%v", userMessage, succeeded, err)
 		}
@@ -0,0 +1,220 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fusion
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+)
+
+// Builds the ResourceData of an update from the existing resource to the new configuration
+func testResourceDataUpdate(t *testing.T, r *schema.Resource, oldRaw, newRaw map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+
+	old := schema.TestResourceDataRaw(t, r.Schema, oldRaw)
+	old.SetId("resource-id")
+	state := old.State()
+
+	diff, err := schema.InternalMap(r.Schema).Diff(context.Background(), state, terraform.NewResourceConfigRaw(newRaw), nil, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %v", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected data error: %v", err)
+	}
+	return d
+}
+
+func testVolumeRaw(restoreFrom ...map[string]interface{}) map[string]interface{} {
+	raw := map[string]interface{}{
+		optionName:               "vol",
+		optionTenant:             "tenant1",
+		optionTenantSpace:        "ts1",
+		optionStorageClass:       "sc1",
+		optionPlacementGroup:     "pg1",
+		optionSize:               "1M",
+		optionHostAccessPolicies: []interface{}{"host0", "host1"},
+	}
+	if len(restoreFrom) != 0 {
+		raw[optionRestoreFrom] = []interface{}{restoreFrom[0]}
+	}
+	return raw
+}
+
+func TestVolumePrepareUpdate_restoreFrom(t *testing.T) {
+	restoreFrom := func(trigger string) map[string]interface{} {
+		return map[string]interface{}{optionSnapshot: "snap1", optionVolumeSnapshot: "vol-snap1", optionTrigger: trigger}
+	}
+	restorePatches := []ResourcePatch{
+		&hmrest.VolumePatch{HostAccessPolicies: &hmrest.NullableString{Value: ""}},
+		&hmrest.VolumePatch{SourceVolumeSnapshotLink: &hmrest.NullableString{
+			Value: "/tenants/tenant1/tenant-spaces/ts1/snapshots/snap1/volume-snapshots/vol-snap1"}},
+		&hmrest.VolumePatch{HostAccessPolicies: &hmrest.NullableString{Value: "host0,host1,attached"}},
+	}
+
+	for _, test := range []struct {
+		name     string
+		oldRaw   map[string]interface{}
+		newRaw   map[string]interface{}
+		expected []ResourcePatch
+	}{
+		{"block added", testVolumeRaw(), testVolumeRaw(restoreFrom("1")), restorePatches},
+		{"trigger changed", testVolumeRaw(restoreFrom("1")), testVolumeRaw(restoreFrom("2")), restorePatches},
+		{"trigger unchanged", testVolumeRaw(restoreFrom("1")), testVolumeRaw(restoreFrom("1")), nil},
+		{"block removed", testVolumeRaw(restoreFrom("1")), testVolumeRaw(), nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			vp := &volumeProvider{BaseResourceProvider{ResourceKind: resourceKindVolume}}
+			d := testResourceDataUpdate(t, resourceVolume(), test.oldRaw, test.newRaw)
+
+			api := &testVolumesPatchAPI{hosts: testVolumeHosts}
+			_, patches, err := vp.PrepareUpdate(context.Background(), &Client{VolumesApi: api}, d)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(patches, test.expected) {
+				t.Errorf("expected patches %v, got %v", test.expected, patches)
+			}
+		})
+	}
+}
+
+// The hosts of the Volume, one of them attached by fusion_volume_host_attachment
+var testVolumeHosts = []string{"host0", "host1", "attached"}
+
+// Records the patches applied to a Volume, completing each of them right away
+type testVolumesPatchAPI struct {
+	VolumesAPI
+	hosts   []string
+	patches []hmrest.VolumePatch
+}
+
+func (api *testVolumesPatchAPI) UpdateVolume(ctx context.Context, body hmrest.VolumePatch, tenantName, tenantSpaceName, volumeName string,
+	opts *hmrest.VolumesApiUpdateVolumeOpts) (hmrest.Operation, *http.Response, error) {
+	api.patches = append(api.patches, body)
+	return hmrest.Operation{Id: "op-id", Status: "Succeeded"}, nil, nil
+}
+
+func (api *testVolumesPatchAPI) GetVolume(ctx context.Context, tenantName, tenantSpaceName, volumeName string,
+	opts *hmrest.VolumesApiGetVolumeOpts) (hmrest.Volume, *http.Response, error) {
+	return api.GetVolumeById(ctx, "resource-id", nil)
+}
+
+func (api *testVolumesPatchAPI) GetVolumeById(ctx context.Context, volumeId string, opts *hmrest.VolumesApiGetVolumeByIdOpts) (hmrest.Volume, *http.Response, error) {
+	var hosts []hmrest.HostAccessPolicyRef
+	for _, host := range api.hosts {
+		hosts = append(hosts, hmrest.HostAccessPolicyRef{Name: host})
+	}
+	return hmrest.Volume{
+		Id:                 volumeId,
+		Name:               "vol",
+		HostAccessPolicies: hosts,
+		Tenant:             &hmrest.TenantRef{Name: "tenant1"},
+		TenantSpace:        &hmrest.TenantSpaceRef{Name: "ts1"},
+		StorageClass:       &hmrest.StorageClassRef{Name: "sc1"},
+		PlacementGroup:     &hmrest.PlacementGroupRef{Name: "pg1"},
+		Size:               1048576,
+	}, nil, nil
+}
+
+func TestVolumeUpdate_patchSequence(t *testing.T) {
+	// Returns the Volume configuration with the given attributes changed
+	changed := func(changes map[string]interface{}) map[string]interface{} {
+		raw := testVolumeRaw()
+		for key, value := range changes {
+			raw[key] = value
+		}
+		return raw
+	}
+	str := func(value string) *hmrest.NullableString {
+		return &hmrest.NullableString{Value: value}
+	}
+
+	for _, test := range []struct {
+		name     string
+		changes  map[string]interface{}
+		expected []hmrest.VolumePatch
+	}{
+		{"nothing", nil, nil},
+		{"display name", map[string]interface{}{optionDisplayName: "Volume"}, []hmrest.VolumePatch{
+			{DisplayName: str("Volume")},
+		}},
+		{"protection policy", map[string]interface{}{optionProtectionPolicy: "pp1"}, []hmrest.VolumePatch{
+			{ProtectionPolicy: str("pp1")},
+		}},
+		{"storage class", map[string]interface{}{optionStorageClass: "sc2"}, []hmrest.VolumePatch{
+			{StorageClass: str("sc2")},
+		}},
+		{"placement group", map[string]interface{}{optionPlacementGroup: "pg2"}, []hmrest.VolumePatch{
+			{HostAccessPolicies: str("")},
+			{PlacementGroup: str("pg2")},
+			{HostAccessPolicies: str("host0,host1,attached")},
+		}},
+		{"storage class and placement group", map[string]interface{}{optionStorageClass: "sc2", optionPlacementGroup: "pg2"}, []hmrest.VolumePatch{
+			{HostAccessPolicies: str("")},
+			{StorageClass: str("sc2"), PlacementGroup: str("pg2")},
+			{HostAccessPolicies: str("host0,host1,attached")},
+		}},
+		{"host access policies", map[string]interface{}{optionHostAccessPolicies: []interface{}{"host1", "host2"}}, []hmrest.VolumePatch{
+			{HostAccessPolicies: str("host1,host2")},
+		}},
+		{"placement group and host access policies", map[string]interface{}{
+			optionPlacementGroup: "pg2", optionHostAccessPolicies: []interface{}{"host2"},
+		}, []hmrest.VolumePatch{
+			{HostAccessPolicies: str("")},
+			{PlacementGroup: str("pg2")},
+			{HostAccessPolicies: str("host2")},
+		}},
+		{"size", map[string]interface{}{optionSize: "2M"}, []hmrest.VolumePatch{
+			{Size: &hmrest.NullableSize{Value: 2097152}},
+		}},
+		{"size in the same units", map[string]interface{}{optionSize: "1024K"}, nil},
+		{"everything", map[string]interface{}{
+			optionDisplayName: "Volume", optionProtectionPolicy: "pp1", optionPlacementGroup: "pg2", optionSize: "2M",
+		}, []hmrest.VolumePatch{
+			{DisplayName: str("Volume")},
+			{ProtectionPolicy: str("pp1")},
+			{HostAccessPolicies: str("")},
+			{PlacementGroup: str("pg2")},
+			{HostAccessPolicies: str("host0,host1,attached")},
+			{Size: &hmrest.NullableSize{Value: 2097152}},
+		}},
+		{"restore and placement group", map[string]interface{}{
+			optionPlacementGroup: "pg2",
+			optionRestoreFrom:    []interface{}{map[string]interface{}{optionSnapshot: "snap1", optionVolumeSnapshot: "vol-snap1", optionTrigger: "1"}},
+		}, []hmrest.VolumePatch{
+			{HostAccessPolicies: str("")},
+			{PlacementGroup: str("pg2")},
+			{SourceVolumeSnapshotLink: str("/tenants/tenant1/tenant-spaces/ts1/snapshots/snap1/volume-snapshots/vol-snap1")},
+			{HostAccessPolicies: str("host0,host1,attached")},
+		}},
+		{"copy from volume", map[string]interface{}{
+			optionSourceLink: []interface{}{map[string]interface{}{optionTenant: "tenant1", optionTenantSpace: "ts1", optionVolume: "vol2"}},
+		}, []hmrest.VolumePatch{
+			{SourceLink: str("/tenants/tenant1/tenant-spaces/ts1/volumes/vol2")},
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			api := &testVolumesPatchAPI{hosts: testVolumeHosts}
+			d := testResourceDataUpdate(t, resourceVolume(), testVolumeRaw(), changed(test.changes))
+
+			if diags := resourceVolume().UpdateContext(context.Background(), d, &Client{VolumesApi: api}); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !reflect.DeepEqual(api.patches, test.expected) {
+				t.Errorf("expected patches %+v, got %+v", test.expected, api.patches)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package httpfixture records the HTTP traffic between the provider and Fusion to a file, and replays it
+// offline. Both the Recorder and the Replayer are http.RoundTrippers, to be used as the transport of the
+// HTTP client of the generated API client.
+package httpfixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type Mode string
+
+const (
+	Off    Mode = ""
+	Record Mode = "record"
+	Replay Mode = "replay"
+)
+
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"` // path and query, without the host
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type Interaction struct {
+	Test     string   `json:"test,omitempty"` // the test which sent the request
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Fixture is the recorded traffic, in the order the responses were received, along with the seeds of the
+// random names each test used, so that the test sends the same requests when replayed.
+// The tests are recorded one at a time, so that each interaction is recorded with the test which sent it.
+type Fixture struct {
+	Seeds        map[string]int64 `json:"seeds"`
+	Interactions []Interaction    `json:"interactions"`
+}
+
+func Load(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("cannot parse HTTP fixture %s: %w", path, err)
+	}
+	if fixture.Seeds == nil {
+		fixture.Seeds = map[string]int64{}
+	}
+	return &fixture, nil
+}
+
+func (f *Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+var redactedPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[^-]*-----END [A-Z ]*PRIVATE KEY-----`),
+	regexp.MustCompile(`"(access_token|refresh_token|id_token|private_key|private_key_password)"\s*:\s*"[^"]*"`),
+}
+
+// Replaces the private keys and tokens in a recorded body
+func Redact(body string) string {
+	body = redactedPatterns[0].ReplaceAllString(body, "REDACTED")
+	return redactedPatterns[1].ReplaceAllString(body, `"$1":"REDACTED"`)
+}
+
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	if redacted.Get("Authorization") != "" {
+		redacted.Set("Authorization", "REDACTED")
+	}
+	return redacted
+}
+
+// Reads the body of the request, and gives the request a fresh one to send
+func readRequest(req *http.Request) (*http.Request, Request, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, Request{}, err
+		}
+	}
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return req, Request{
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+		Header: redactHeader(req.Header),
+		Body:   Redact(string(body)),
+	}, nil
+}
+
+// Recorder sends the requests with the Base transport and records them along with their responses
+type Recorder struct {
+	Base http.RoundTripper
+
+	mu      sync.Mutex
+	test    string
+	fixture Fixture
+}
+
+func NewRecorder(base http.RoundTripper) *Recorder {
+	return &Recorder{Base: base, fixture: Fixture{Seeds: map[string]int64{}}}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, recorded, err := readRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fixture.Interactions = append(r.fixture.Interactions, Interaction{
+		Test:     r.test,
+		Request:  recorded,
+		Response: Response{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: Redact(string(body))},
+	})
+	return resp, nil
+}
+
+// Records the seed of the random names of a test
+func (r *Recorder) SetSeed(test string, seed int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fixture.Seeds[test] = seed
+}
+
+// Records the next requests as sent by the test, "" when no test is running
+func (r *Recorder) SetTest(test string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.test = test
+}
+
+func (r *Recorder) Save(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fixture.Save(path)
+}
+
+// Replayer answers the requests with the recorded responses, without sending them. A request is answered
+// by the first unused response the running test recorded for the same method, URL and body, so that
+// concurrent requests are answered the same whichever order they come in. Requests nothing was recorded for fail.
+type Replayer struct {
+	mu    sync.Mutex
+	seeds map[string]int64
+	test  string
+	// The tests which were replayed
+	ran map[string]bool
+	// The responses not replayed yet, per test, then per request
+	pending map[string]map[string][]Response
+}
+
+func NewReplayer(fixture *Fixture) *Replayer {
+	r := &Replayer{seeds: fixture.Seeds, ran: map[string]bool{}, pending: map[string]map[string][]Response{}}
+	for _, interaction := range fixture.Interactions {
+		if r.pending[interaction.Test] == nil {
+			r.pending[interaction.Test] = map[string][]Response{}
+		}
+		key := requestKey(interaction.Request)
+		r.pending[interaction.Test][key] = append(r.pending[interaction.Test][key], interaction.Response)
+	}
+	return r
+}
+
+func requestKey(req Request) string {
+	return req.Method + " " + req.URL + " " + req.Body
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, recorded, err := readRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := requestKey(recorded)
+	responses := r.pending[r.test][key]
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("no recorded response left for %s %s", recorded.Method, recorded.URL)
+	}
+	recordedResp := responses[0]
+	r.pending[r.test][key] = responses[1:]
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recordedResp.StatusCode, http.StatusText(recordedResp.StatusCode)),
+		StatusCode:    recordedResp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recordedResp.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recordedResp.Body)),
+		ContentLength: int64(len(recordedResp.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Returns the seed of the random names of a test as recorded, false if the test was not recorded
+func (r *Replayer) Seed(test string) (int64, bool) {
+	seed, ok := r.seeds[test]
+	return seed, ok
+}
+
+// Answers the next requests with the responses the test recorded, "" when no test is running
+func (r *Replayer) SetTest(test string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.test = test
+	if test != "" {
+		r.ran[test] = true
+	}
+}
+
+// Returns the test, method and URL of the requests recorded by the tests which were replayed, but which
+// were not replayed themselves, sorted. The requests of the tests which did not run are not reported.
+func (r *Replayer) Unused() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []string
+	for test, pending := range r.pending {
+		if !r.ran[test] {
+			continue
+		}
+		for key, responses := range pending {
+			methodAndURL := strings.Join(strings.SplitN(key, " ", 3)[:2], " ")
+			for range responses {
+				unused = append(unused, test+": "+methodAndURL)
+			}
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
@@ -2,7 +2,9 @@ package utilities
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -10,6 +12,30 @@ import (
 	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
+// OperationsAPI is the part of the Operations service WaitOnOperation polls, implemented by hmrest.OperationsApiService
+type OperationsAPI interface {
+	GetOperation(ctx context.Context, id string, opts *hmrest.OperationsApiGetOperationOpts) (hmrest.Operation, *http.Response, error)
+}
+
+// OperationWaitError is returned when we stop waiting for an operation which has not finished yet,
+// e.g. because the resource timeout expired or the user interrupted Terraform.
+// The operation itself is not cancelled and may still complete.
+type OperationWaitError struct {
+	OperationId string
+	RequestType string
+	Status      string
+	Err         error
+}
+
+func (e *OperationWaitError) Error() string {
+	return fmt.Sprintf("stopped waiting for operation %s (request type: %s, last status: %s), it may still be running: %s",
+		e.OperationId, e.RequestType, e.Status, e.Err)
+}
+
+func (e *OperationWaitError) Unwrap() error {
+	return e.Err
+}
+
 // Wait on an operation until its status reaches Succeeded (or Completed) or Failed.
 // Return succeeded = true if status reaches Succeeded (or Completed), Failed if status reached Failed, and err otherwise.
 // On return,
@@ -17,7 +43,9 @@ import (
 //	 op will be up to date with the most recent GET of the operation, EVEN when we're returning an error.
 //		if err != nil, then we have an error. Ignore succeeded (it will be false, but it doesn't mean the operation failed.)
 //	 If err == nil, then check succeeded. It is true iff (op.Status == "Succeeded" || op.Status == "Completed") && op.Status != "Failed"
-func WaitOnOperation(ctx context.Context, op *hmrest.Operation, client *hmrest.APIClient) (succeeded bool, err error) {
+//
+// Waiting stops with *OperationWaitError when ctx is done, e.g. when the resource timeout expires.
+func WaitOnOperation(ctx context.Context, op *hmrest.Operation, operations OperationsAPI) (succeeded bool, err error) {
 	TraceOperation(ctx, op, "waitOnOperation")
 	tflog.Debug(ctx, "Waiting for operation",
 		"op_type", op.RequestType,
@@ -29,11 +57,20 @@ func WaitOnOperation(ctx context.Context, op *hmrest.Operation, client *hmrest.A
 		return false, fmt.Errorf("waitOnOperation with null op")
 	}
 	for op.Status != "Succeeded" && op.Status != "Completed" && op.Status != "Failed" {
-		time.Sleep(time.Duration(op.RetryIn) * time.Millisecond)
-		opNew, _, err := client.OperationsApi.GetOperation(ctx, op.Id, nil)
+		timer := time.NewTimer(time.Duration(op.RetryIn) * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false, newOperationWaitError(ctx, op, ctx.Err())
+		case <-timer.C:
+		}
+		opNew, _, err := operations.GetOperation(ctx, op.Id, nil)
 		TraceOperation(ctx, &opNew, "waitOnOperation")
 		TraceError(ctx, err)
 		if err != nil {
+			if ctx.Err() != nil {
+				return false, newOperationWaitError(ctx, op, ctx.Err())
+			}
 			return false, err
 		}
 		*op = opNew
@@ -52,19 +89,54 @@ func WaitOnOperation(ctx context.Context, op *hmrest.Operation, client *hmrest.A
 	return true, nil
 }
 
+func newOperationWaitError(ctx context.Context, op *hmrest.Operation, err error) *OperationWaitError {
+	tflog.Error(ctx, "waitOnOperation interrupted",
+		"op_type", op.RequestType,
+		"op_id", op.Id,
+		"op_status", op.Status,
+		"error_message", err.Error())
+	return &OperationWaitError{OperationId: op.Id, RequestType: op.RequestType, Status: op.Status, Err: err}
+}
+
 func ProcessClientError(ctx context.Context, op string, err error) diag.Diagnostics {
 	TraceError(ctx, err)
+	var waitErr *OperationWaitError
+	if errors.As(err, &waitErr) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Stopped waiting for operation %s", waitErr.OperationId),
+			Detail: fmt.Sprintf("Operation %s (%s) was still %s when %s was interrupted: %s. "+
+				"The operation has not been cancelled and may still complete, check its status before retrying.",
+				waitErr.OperationId, waitErr.RequestType, waitErr.Status, op, waitErr.Err),
+		}}
+	}
+	var restError *RestError
+	if errors.As(err, &restError) {
+		tflog.Error(ctx, "REST operation failed",
+			"operation", op,
+			"op_id", restError.OperationId,
+			"error_message", restError.Message,
+			"PureCode", restError.PureCode,
+			"HttpCode", restError.HttpCode)
+		return diag.Diagnostics{restError.Diagnostic()}
+	}
 	modelError, convError := hmrest.ToModelError(err)
-	if convError != nil {
+	if convError != nil || modelError == nil {
 		tflog.Warn(ctx, "Error while converting error",
-			"error_message", convError.Error(),
+			"error_message", fmt.Sprint(convError),
 			"unconverted error", err,
 			"operation", op)
 		return diag.FromErr(err)
 	} else {
+		restError := newRestError(op, modelError)
+		// ToModelError succeeded, err wraps the GenericSwaggerError of the failed response
+		var swagErr hmrest.GenericSwaggerError
+		errors.As(err, &swagErr)
+		restError.RequestId = failedRequestId(ctx, swagErr.Body())
 		tflog.Error(ctx, "REST ",
 			"operation", op,
+			"request_id", restError.RequestId,
 			"error_message", modelError.Message)
-		return diag.Errorf(modelError.Message)
+		return diag.Diagnostics{restError.Diagnostic()}
 	}
 }
@@ -0,0 +1,170 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+package utilities_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Serves GET /operations/{id}, finishing the operation after the given number of polls
+func testOperationServer(t *testing.T, pollsToFinish int) *hmrest.APIClient {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		op := hmrest.Operation{
+			Id:          strings.TrimPrefix(r.URL.Path, "/operations/"),
+			RequestType: "CreateVolume",
+			Status:      "Running",
+			RetryIn:     10,
+		}
+		if pollsToFinish >= 0 && polls >= pollsToFinish {
+			op.Status = "Succeeded"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(op)
+	}))
+	t.Cleanup(server.Close)
+
+	return hmrest.NewAPIClient(&hmrest.Configuration{
+		BasePath:      server.URL,
+		DefaultHeader: map[string]string{},
+		HTTPClient:    server.Client(),
+	})
+}
+
+func TestWaitOnOperation_succeeds(t *testing.T) {
+	client := testOperationServer(t, 2)
+	op := hmrest.Operation{Id: "op-1", Status: "Pending", RetryIn: 10}
+
+	succeeded, err := utilities.WaitOnOperation(context.Background(), &op, client.OperationsApi)
+	require.NoError(t, err)
+	assert.True(t, succeeded)
+	assert.Equal(t, "Succeeded", op.Status)
+}
+
+func TestWaitOnOperation_stopsOnDeadline(t *testing.T) {
+	client := testOperationServer(t, -1)
+	op := hmrest.Operation{Id: "op-1", RequestType: "CreateVolume", Status: "Pending", RetryIn: 10}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	succeeded, err := utilities.WaitOnOperation(ctx, &op, client.OperationsApi)
+	assert.False(t, succeeded)
+
+	var waitErr *utilities.OperationWaitError
+	require.True(t, errors.As(err, &waitErr), "unexpected error: %v", err)
+	assert.Equal(t, "op-1", waitErr.OperationId)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	diags := utilities.ProcessClientError(ctx, "create", err)
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Summary, "op-1")
+}
+
+func TestProcessClientError_restError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(hmrest.ErrorResponse{Error_: &hmrest.ModelError{
+			Message:  "size must be at most 100% of the quota",
+			PureCode: "INVALID_ARGUMENT",
+			HttpCode: http.StatusUnprocessableEntity,
+			Details:  map[string]string{"field": "size", "max": "1T"},
+		}})
+	}))
+	t.Cleanup(server.Close)
+	client := hmrest.NewAPIClient(&hmrest.Configuration{BasePath: server.URL, DefaultHeader: map[string]string{}, HTTPClient: server.Client()})
+
+	_, _, err := client.OperationsApi.GetOperation(context.Background(), "op-1", nil)
+	require.Error(t, err)
+
+	diags := utilities.ProcessClientError(context.Background(), "read", err)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "size must be at most 100% of the quota", diags[0].Summary)
+	assert.Equal(t, "Pure code: INVALID_ARGUMENT\nHTTP code: 422\nfield: size\nmax: 1T", diags[0].Detail)
+	assert.Equal(t, cty.GetAttrPath("size"), diags[0].AttributePath)
+}
+
+func TestProcessClientError_rejectedRequest(t *testing.T) {
+	requestIds := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIds[r.Method] = r.Header.Get("X-Request-ID")
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(hmrest.ErrorResponse{Error_: &hmrest.ModelError{
+				Message:  "operation not found",
+				PureCode: "NOT_FOUND",
+				HttpCode: http.StatusNotFound,
+			}})
+			return
+		}
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(hmrest.ErrorResponse{Error_: &hmrest.ModelError{
+			Message:  "tenant already exists",
+			PureCode: "ALREADY_EXISTS",
+			HttpCode: http.StatusConflict,
+		}})
+	}))
+	t.Cleanup(server.Close)
+	// Request IDs are reported even when requests are not retried
+	client := hmrest.NewAPIClient(&hmrest.Configuration{
+		BasePath:      server.URL,
+		DefaultHeader: map[string]string{},
+		HTTPClient: &http.Client{Transport: utilities.NewRequestIdTransport(
+			utilities.NewRetryTransport(server.Client().Transport, 0, time.Millisecond))},
+	})
+
+	ctx := utilities.WithRequestIdRecorder(context.Background())
+	_, _, err := client.TenantsApi.CreateTenant(ctx, hmrest.TenantPost{Name: "tenant1"}, nil)
+	require.Error(t, err)
+	// Requests failing after the first one must not change the request ID it reports
+	_, _, getErr := client.OperationsApi.GetOperation(ctx, "op-1", nil)
+	require.Error(t, getErr)
+	require.NotEmpty(t, requestIds[http.MethodPost])
+	require.NotEmpty(t, requestIds[http.MethodGet])
+
+	diags := utilities.ProcessClientError(ctx, "create", fmt.Errorf("creating tenant: %w", err))
+	require.Len(t, diags, 1)
+	assert.Equal(t, "tenant already exists", diags[0].Summary)
+	assert.Equal(t, "Pure code: ALREADY_EXISTS\nHTTP code: 409\nRequest ID: "+requestIds[http.MethodPost], diags[0].Detail)
+
+	diags = utilities.ProcessClientError(ctx, "read", getErr)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Pure code: NOT_FOUND\nHTTP code: 404\nRequest ID: "+requestIds[http.MethodGet], diags[0].Detail)
+}
+
+func TestProcessClientError_failedOperation(t *testing.T) {
+	op := hmrest.Operation{
+		Id:          "op-1",
+		RequestId:   "req-1",
+		RequestType: "CreateVolume",
+		Status:      "Failed",
+		Error_:      &hmrest.ModelError{Message: "no space left", PureCode: "EXHAUSTED", HttpCode: http.StatusConflict},
+	}
+	err := fmt.Errorf("creating volume: %w", utilities.NewRestErrorFromOperation(&op))
+
+	diags := utilities.ProcessClientError(context.Background(), "create", err)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "no space left", diags[0].Summary)
+	assert.Equal(t, "Pure code: EXHAUSTED\nHTTP code: 409\nOperation ID: op-1 (CreateVolume)\nRequest ID: req-1", diags[0].Detail)
+	assert.Nil(t, diags[0].AttributePath)
+}
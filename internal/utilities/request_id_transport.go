@@ -0,0 +1,126 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utilities
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// RequestIdTransport is an http.RoundTripper which gives GET, HEAD, POST and PATCH requests without an X-Request-ID
+// header a newly generated one. Fusion recognizes a replayed request by it, so RetryTransport retries the requests
+// carrying it, and support can find any request by it.
+// Fusion does not echo it in the errors it returns synchronously, unlike failed operations: the transport remembers
+// the request ID of each failed response on the context set up by WithRequestIdRecorder, for ProcessClientError.
+type RequestIdTransport struct {
+	Base http.RoundTripper
+}
+
+func NewRequestIdTransport(base http.RoundTripper) *RequestIdTransport {
+	return &RequestIdTransport{Base: base}
+}
+
+func (t *RequestIdTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get(requestIdHeader) == "" && needsRequestId(req) {
+		if requestId, err := newRequestId(); err == nil {
+			// RoundTrippers must not modify the request, so work on a copy with its own headers
+			req = req.Clone(req.Context())
+			req.Header.Set(requestIdHeader, requestId)
+		}
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil || resp.StatusCode < 400 {
+		return resp, err
+	}
+	recorder, ok := req.Context().Value(requestIdRecorderKey{}).(*requestIdRecorder)
+	if !ok {
+		return resp, nil
+	}
+	// The generated client keeps only the body of the failed response in its error, so that is what identifies it
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	recorder.record(req.Header.Get(requestIdHeader), body)
+	return resp, nil
+}
+
+func (t *RequestIdTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// DELETE and PUT go without one, so that they are not retried
+func needsRequestId(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPatch:
+		return true
+	}
+	return false
+}
+
+type requestIdRecorderKey struct{}
+
+type failedResponse struct {
+	requestId string
+	body      []byte
+}
+
+// Remembers the request ID of the failed responses to the requests sent with a context
+type requestIdRecorder struct {
+	mutex     sync.Mutex
+	responses []failedResponse
+}
+
+// WithRequestIdRecorder returns a context which remembers the request ID of the failed responses
+// to the requests sent with it, so that ProcessClientError can report them
+func WithRequestIdRecorder(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestIdRecorderKey{}, &requestIdRecorder{})
+}
+
+func (r *requestIdRecorder) record(requestId string, body []byte) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.responses = append(r.responses, failedResponse{requestId, body})
+}
+
+// Returns the request ID sent with the request which failed with the body, "" if it had none or is not recorded.
+// The latest one wins when several requests failed with the same body.
+func failedRequestId(ctx context.Context, body []byte) string {
+	recorder, ok := ctx.Value(requestIdRecorderKey{}).(*requestIdRecorder)
+	if !ok {
+		return ""
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	for i := len(recorder.responses) - 1; i >= 0; i-- {
+		if bytes.Equal(recorder.responses[i].body, body) {
+			return recorder.responses[i].requestId
+		}
+	}
+	return ""
+}
+
+// Random (version 4) UUID
+func newRequestId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
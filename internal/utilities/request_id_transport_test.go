@@ -0,0 +1,38 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+package utilities_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+func TestRequestIdTransport_generatesRequestIds(t *testing.T) {
+	server, requests, _ := testFlakyServer(t)
+	// Without retrying, requests still get a request ID
+	client := &http.Client{Transport: utilities.NewRequestIdTransport(utilities.NewRetryTransport(server.Client().Transport, 0, 0))}
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodDelete} {
+		req, err := http.NewRequest(method, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	require.Len(t, *requests, 4)
+	ids := map[string]bool{}
+	for _, r := range (*requests)[:3] {
+		ids[r.Header.Get("X-Request-ID")] = true
+	}
+	assert.Len(t, ids, 3)
+	assert.NotContains(t, ids, "")
+	assert.Empty(t, (*requests)[3].Header.Get("X-Request-ID"), "DELETE requests must not become retryable")
+}
@@ -7,35 +7,104 @@ package utilities
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	hmrest "github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/hmrest"
 )
 
+// The keys of the error details in which Fusion names the request field an error is about
+var fieldDetailKeys = []string{"field", "parameter"}
+
 type RestError struct {
 	OperationType string
+	OperationId   string
+	RequestId     string
 	PureCode      string
 	HttpCode      string
 	Message       string
+	Details       map[string]string
 }
 
 func NewRestErrorFromOperation(operation *hmrest.Operation) *RestError {
+	restError := newRestError(operation.RequestType, operation.Error_)
+	restError.OperationId = operation.Id
+	restError.RequestId = operation.RequestId
+	return restError
+}
+
+func newRestError(operationType string, modelError *hmrest.ModelError) *RestError {
 	pureCode := "unknown"
 	httpCode := "unknown"
 	message := "reason unknown"
-	if operation.Error_ != nil {
-		pureCode = operation.Error_.PureCode
-		httpCode = strconv.FormatInt(int64(operation.Error_.HttpCode), 10)
-		message = operation.Error_.Message
+	var details map[string]string
+	if modelError != nil {
+		pureCode = modelError.PureCode
+		if modelError.HttpCode != 0 {
+			httpCode = strconv.FormatInt(int64(modelError.HttpCode), 10)
+		}
+		message = modelError.Message
+		details = modelError.Details
 	}
 	return &RestError{
-		OperationType: operation.RequestType,
+		OperationType: operationType,
 		PureCode:      pureCode,
 		HttpCode:      httpCode,
 		Message:       message,
+		Details:       details,
 	}
 }
 
 func (e *RestError) Error() string {
-	return fmt.Sprintf("operation '%v' failed: %v (Pure '%v', Http %v)", e.OperationType, e.Message, e.PureCode, e.HttpCode)
+	operation := e.OperationType
+	if e.OperationId != "" {
+		operation += " " + e.OperationId
+	}
+	return fmt.Sprintf("operation '%v' failed: %v (Pure '%v', Http %v)", operation, e.Message, e.PureCode, e.HttpCode)
+}
+
+// Returns the name of the request field the error is about, "" if Fusion did not tell
+func (e *RestError) Field() string {
+	for _, key := range fieldDetailKeys {
+		if field := e.Details[key]; field != "" {
+			return field
+		}
+	}
+	return ""
+}
+
+// Diagnostic summarizes the error with its message, and lists the codes, IDs and details Fusion returned
+// to act on it. The attribute path is set when the error names a field, whose name is assumed to match
+// the attribute of the resource.
+func (e *RestError) Diagnostic() diag.Diagnostic {
+	var detail strings.Builder
+	fmt.Fprintf(&detail, "Pure code: %s\nHTTP code: %s", e.PureCode, e.HttpCode)
+	if e.OperationId != "" {
+		fmt.Fprintf(&detail, "\nOperation ID: %s (%s)", e.OperationId, e.OperationType)
+	}
+	if e.RequestId != "" {
+		fmt.Fprintf(&detail, "\nRequest ID: %s", e.RequestId)
+	}
+	keys := make([]string, 0, len(e.Details))
+	for key := range e.Details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&detail, "\n%s: %s", key, e.Details[key])
+	}
+
+	result := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  e.Message,
+		Detail:   detail.String(),
+	}
+	if field := e.Field(); field != "" {
+		result.AttributePath = cty.GetAttrPath(field)
+	}
+	return result
 }
@@ -0,0 +1,168 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utilities
+
+import (
+	"context"
+	"crypto/rand"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DefaultMaxRetries      = 5
+	DefaultRetryMinBackoff = 500 * time.Millisecond
+	DefaultRetryMaxBackoff = 30 * time.Second
+
+	requestIdHeader = "X-Request-ID"
+)
+
+// RetryTransport is an http.RoundTripper which retries requests throttled (429) or failed (5xx) by the server.
+// GET and HEAD requests are always retried. Other requests are retried only if they carry an X-Request-ID header,
+// which lets Fusion recognize a replayed request instead of executing it twice. Wrap it in a RequestIdTransport,
+// so that requests without the header get one shared by all the attempts.
+type RetryTransport struct {
+	Base http.RoundTripper
+
+	// Number of retries after the first attempt, 0 disables retrying
+	MaxRetries int
+	// The wait before the first retry, it doubles with every next retry
+	MinBackoff time.Duration
+	// Upper bound of the exponential backoff. A longer Retry-After returned by the server is still honored.
+	MaxBackoff time.Duration
+}
+
+func NewRetryTransport(base http.RoundTripper, maxRetries int, maxBackoff time.Duration) *RetryTransport {
+	return &RetryTransport{Base: base, MaxRetries: maxRetries, MinBackoff: DefaultRetryMinBackoff, MaxBackoff: maxBackoff}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.MaxRetries <= 0 || req.Body != nil && req.Body != http.NoBody && req.GetBody == nil || !isRetryableMethod(req) {
+		return t.base().RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base().RoundTrip(attemptReq)
+		if attempt >= t.MaxRetries || !isRetryableResponse(ctx, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		logRetry(ctx, req, attempt, wait, resp, err)
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		attemptReq = req.Clone(ctx)
+		if req.GetBody != nil {
+			attemptReq.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (t *RetryTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// Exponential backoff with jitter, unless the server told us how long to wait
+func (t *RetryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	backoff := t.MaxBackoff
+	if attempt < 32 && t.MinBackoff<<attempt < t.MaxBackoff && t.MinBackoff<<attempt > 0 {
+		backoff = t.MinBackoff << attempt
+	}
+	if backoff <= 1 {
+		return backoff
+	}
+
+	// Wait at least half of the backoff, randomize the rest so that parallel requests don't retry in lockstep
+	jitter, err := rand.Int(rand.Reader, big.NewInt(int64(backoff/2)))
+	if err != nil {
+		return backoff
+	}
+	return backoff/2 + time.Duration(jitter.Int64())
+}
+
+func isRetryableMethod(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+	return req.Header.Get(requestIdHeader) != ""
+}
+
+func isRetryableResponse(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		// Errors caused by the cancelled context must not be retried
+		return ctx.Err() == nil
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented
+}
+
+// Retry-After is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func logRetry(ctx context.Context, req *http.Request, attempt int, wait time.Duration, resp *http.Response, err error) {
+	fields := []interface{}{
+		"method", req.Method,
+		"url", req.URL.String(),
+		"request_id", req.Header.Get(requestIdHeader),
+		"attempt_done_count", attempt + 1,
+		"cooldown_ms", wait.Milliseconds(),
+	}
+	if err != nil {
+		fields = append(fields, "error_message", err.Error())
+	} else {
+		fields = append(fields, "HttpCode", resp.StatusCode)
+	}
+	tflog.Warn(ctx, "retrying_http_request", fields...)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2023 Pure Storage Inc
+SPDX-License-Identifier: Apache-2.0
+*/
+package utilities_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PureStorage-OpenConnect/terraform-provider-fusion/internal/utilities"
+)
+
+// Responds with the given status codes in order, then with 200
+func testFlakyServer(t *testing.T, codes ...int) (*httptest.Server, *[]*http.Request, *[]string) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, string(body))
+		if len(requests) <= len(codes) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(codes[len(requests)-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests, &bodies
+}
+
+func testRetryClient(maxRetries int) *http.Client {
+	return &http.Client{Transport: utilities.NewRequestIdTransport(&utilities.RetryTransport{
+		MaxRetries: maxRetries,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+	})}
+}
+
+func TestRetryTransport_retriesGet(t *testing.T) {
+	server, requests, _ := testFlakyServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusBadGateway)
+
+	resp, err := testRetryClient(5).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, *requests, 4)
+}
+
+func TestRetryTransport_givesUpAfterMaxRetries(t *testing.T) {
+	server, requests, _ := testFlakyServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	resp, err := testRetryClient(2).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Len(t, *requests, 3)
+}
+
+func TestRetryTransport_doesNotRetryClientErrors(t *testing.T) {
+	server, requests, _ := testFlakyServer(t, http.StatusBadRequest, http.StatusNotImplemented)
+
+	resp, err := testRetryClient(5).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Len(t, *requests, 1)
+}
+
+func TestRetryTransport_replaysPostWithSameRequestId(t *testing.T) {
+	server, requests, bodies := testFlakyServer(t, http.StatusInternalServerError, http.StatusServiceUnavailable)
+
+	resp, err := testRetryClient(5).Post(server.URL, "application/json", strings.NewReader(`{"name":"vol1"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, *requests, 3)
+	requestId := (*requests)[0].Header.Get("X-Request-ID")
+	assert.NotEmpty(t, requestId)
+	for i, r := range *requests {
+		assert.Equal(t, requestId, r.Header.Get("X-Request-ID"))
+		assert.Equal(t, `{"name":"vol1"}`, (*bodies)[i])
+	}
+}
+
+func TestRetryTransport_doesNotRetryPostWithoutRequestId(t *testing.T) {
+	server, requests, _ := testFlakyServer(t, http.StatusServiceUnavailable)
+	client := &http.Client{Transport: &utilities.RetryTransport{MaxRetries: 5, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Len(t, *requests, 1)
+}
+
+func TestRetryTransport_keepsCallerRequestId(t *testing.T) {
+	server, requests, _ := testFlakyServer(t, http.StatusServiceUnavailable)
+
+	req, err := http.NewRequest(http.MethodPatch, server.URL, strings.NewReader(`{}`))
+	require.NoError(t, err)
+	req.Header.Set("X-Request-ID", "my-request")
+
+	resp, err := testRetryClient(5).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Len(t, *requests, 2)
+	assert.Equal(t, "my-request", (*requests)[1].Header.Get("X-Request-ID"))
+}
+
+func TestRetryTransport_doesNotRetryDelete(t *testing.T) {
+	server, requests, _ := testFlakyServer(t, http.StatusServiceUnavailable)
+
+	req, err := http.NewRequest(http.MethodDelete, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := testRetryClient(5).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Len(t, *requests, 1)
+}
+
+func TestRetryTransport_honorsRetryAfter(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	resp, err := testRetryClient(5).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}